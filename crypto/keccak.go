@@ -0,0 +1,24 @@
+// Package crypto implements the cryptographic primitives used by Ethereum
+package crypto
+
+import (
+	"hash"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// NewKeccak256 returns a new Keccak-256 hash.Hash. This is the legacy
+// Keccak-256 used by Ethereum, which differs from the standardized SHA3-256
+// in its padding.
+func NewKeccak256() hash.Hash {
+	return sha3.NewLegacyKeccak256()
+}
+
+// Keccak256 returns the Keccak-256 hash of the concatenated data
+func Keccak256(data ...[]byte) []byte {
+	h := NewKeccak256()
+	for _, b := range data {
+		h.Write(b)
+	}
+	return h.Sum(nil)
+}
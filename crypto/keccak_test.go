@@ -0,0 +1,43 @@
+package crypto
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+func TestKeccak256(t *testing.T) {
+
+	tests := []struct {
+		input string
+		hash  string
+	}{
+		{"", "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470"},
+		{"abc", "4e03657aea45a94fc7d47ba826c8d667c0d1e6e33a64a036ec44f58fa12d6c45"},
+		{"hello world", "47173285a8d7341e5e972fc677286384f802f8ef42a5ec5f03bbfa254cb01fad"},
+	}
+	for _, test := range tests {
+		hash := hex.EncodeToString(Keccak256([]byte(test.input)))
+		if hash != test.hash {
+			t.Errorf("Keccak256(%q) = %s, want %s", test.input, hash, test.hash)
+		}
+	}
+}
+
+func TestKeccak256Concatenates(t *testing.T) {
+
+	// longer than the 136 byte rate, split across the block boundary
+	data := bytes.Repeat([]byte{0xab}, 300)
+	want := Keccak256(data)
+	if got := Keccak256(data[:100], data[100:137], data[137:]); !bytes.Equal(got, want) {
+		t.Errorf("Keccak256 of parts = %x, want %x", got, want)
+	}
+
+	h := NewKeccak256()
+	h.Write(data[:200])
+	h.Sum(nil)
+	h.Write(data[200:])
+	if got := h.Sum(nil); !bytes.Equal(got, want) {
+		t.Errorf("Sum after Sum = %x, want %x", got, want)
+	}
+}
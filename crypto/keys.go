@@ -0,0 +1,93 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+var (
+	// secp256k1N is the order of the secp256k1 curve
+	secp256k1N = secp256k1.S256().N
+	// secp256k1HalfN bounds the S value of canonical signatures (EIP-2)
+	secp256k1HalfN = new(big.Int).Rsh(secp256k1N, 1)
+)
+
+// ErrInvalidPrivateKey is returned for private keys outside [1, n)
+var ErrInvalidPrivateKey = errors.New("invalid secp256k1 private key")
+
+// PublicKey is a secp256k1 public key
+type PublicKey struct {
+	X *big.Int
+	Y *big.Int
+}
+
+// PrivateKey is a secp256k1 private key
+type PrivateKey struct {
+	PublicKey
+	D *big.Int
+}
+
+// GenerateKey returns a new random private key
+func GenerateKey() (*PrivateKey, error) {
+	for {
+		b := make([]byte, 32)
+		_, err := rand.Read(b)
+		if err != nil {
+			return nil, err
+		}
+		key, err := ToPrivateKey(b)
+		if err == nil {
+			return key, nil
+		}
+	}
+}
+
+// ToPrivateKey returns the private key with the given 32 byte big-endian
+// scalar
+func ToPrivateKey(b []byte) (*PrivateKey, error) {
+	if len(b) != 32 {
+		return nil, ErrInvalidPrivateKey
+	}
+	d := new(big.Int).SetBytes(b)
+	if d.Sign() == 0 || d.Cmp(secp256k1N) >= 0 {
+		return nil, ErrInvalidPrivateKey
+	}
+	pub := secp256k1.PrivKeyFromBytes(b).PubKey()
+	return &PrivateKey{PublicKey: PublicKey{X: pub.X(), Y: pub.Y()}, D: d}, nil
+}
+
+// HexToPrivateKey returns the private key with the given hex encoded scalar,
+// with or without a 0x prefix
+func HexToPrivateKey(s string) (*PrivateKey, error) {
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidPrivateKey
+	}
+	return ToPrivateKey(b)
+}
+
+// Bytes returns the 32 byte big-endian scalar of the private key
+func (key *PrivateKey) Bytes() []byte {
+	return key.D.FillBytes(make([]byte, 32))
+}
+
+// Bytes returns the 65 byte uncompressed encoding of the public key
+func (pub *PublicKey) Bytes() []byte {
+	b := make([]byte, 65)
+	b[0] = 0x04
+	pub.X.FillBytes(b[1:33])
+	pub.Y.FillBytes(b[33:])
+	return b
+}
+
+// Address returns the 20 byte Ethereum address of the public key: the last
+// 20 bytes of the keccak256 hash of its coordinates
+func (pub *PublicKey) Address() []byte {
+	return Keccak256(pub.Bytes()[1:])[12:]
+}
@@ -0,0 +1,70 @@
+package crypto
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestPrivateKeyAddress(t *testing.T) {
+
+	tests := []struct {
+		key     string
+		address string
+	}{
+		// go-ethereum crypto tests
+		{"289c2857d4598e37fb9647507e47a309d6133539bf21a8b9cb6df88fd5232032", "970e8128ab834e8eac17ab8e3812f010678cf791"},
+		// EIP-155 example
+		{"0x4646464646464646464646464646464646464646464646464646464646464646", "9d8a62f656a8d1615c1294fd71e9cfb3e4855a4f"},
+	}
+	for _, test := range tests {
+		key, err := HexToPrivateKey(test.key)
+		if err != nil {
+			t.Fatalf("HexToPrivateKey(%s): %v", test.key, err)
+		}
+		address := hex.EncodeToString(key.Address())
+		if address != test.address {
+			t.Errorf("address of %s = %s, want %s", test.key, address, test.address)
+		}
+	}
+}
+
+func TestToPrivateKeyRange(t *testing.T) {
+
+	tests := []string{
+		"",
+		"0000000000000000000000000000000000000000000000000000000000000000",
+		"fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141", // n
+		"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		"0102",
+		"zz",
+	}
+	for _, test := range tests {
+		_, err := HexToPrivateKey(test)
+		if err != ErrInvalidPrivateKey {
+			t.Errorf("HexToPrivateKey(%q) error = %v, want ErrInvalidPrivateKey", test, err)
+		}
+	}
+
+	key, err := HexToPrivateKey("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364140")
+	if err != nil {
+		t.Fatalf("HexToPrivateKey(n - 1): %v", err)
+	}
+	if got := hex.EncodeToString(key.Bytes()); got != "fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364140" {
+		t.Errorf("Bytes() = %s", got)
+	}
+}
+
+func TestGenerateKey(t *testing.T) {
+
+	key, err := GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	key2, err := ToPrivateKey(key.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key2.X.Cmp(key.X) != 0 || key2.Y.Cmp(key.Y) != 0 {
+		t.Errorf("public key of the generated key bytes differs")
+	}
+}
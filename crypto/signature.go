@@ -0,0 +1,82 @@
+package crypto
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+)
+
+// ErrInvalidSignature is returned when no public key can be recovered from a
+// signature
+var ErrInvalidSignature = errors.New("invalid secp256k1 signature")
+
+// SIGNATURE_LENGTH is the length of a recoverable signature: R, S and the
+// recovery ID
+const SIGNATURE_LENGTH = 65
+
+// Sign signs a 32 byte hash, returning the 65 byte recoverable signature
+// [R || S || V] where V is the recovery ID, 0 or 1. The nonce is derived
+// deterministically (RFC 6979) and S is in the lower half of the curve
+// order (EIP-2).
+func Sign(hash []byte, key *PrivateKey) ([]byte, error) {
+
+	if len(hash) != 32 {
+		return nil, errors.New("hash must be 32 bytes")
+	}
+	if key.D.Sign() <= 0 || key.D.Cmp(secp256k1N) >= 0 {
+		return nil, ErrInvalidPrivateKey
+	}
+
+	priv := secp256k1.PrivKeyFromBytes(key.Bytes())
+	defer priv.Zero()
+	// the compact signature is [27 + V || R || S]
+	compact := ecdsa.SignCompact(priv, hash, false)
+
+	sig := make([]byte, SIGNATURE_LENGTH)
+	copy(sig, compact[1:])
+	sig[64] = compact[0] - 27
+	return sig, nil
+}
+
+// RecoverPublicKey returns the public key whose private key produced the 65
+// byte recoverable signature [R || S || V] of hash, where V is the recovery
+// ID
+func RecoverPublicKey(hash, sig []byte) (*PublicKey, error) {
+
+	if len(hash) != 32 {
+		return nil, errors.New("hash must be 32 bytes")
+	}
+	if len(sig) != SIGNATURE_LENGTH {
+		return nil, fmt.Errorf("signature must be %d bytes", SIGNATURE_LENGTH)
+	}
+	r := new(big.Int).SetBytes(sig[:32])
+	s := new(big.Int).SetBytes(sig[32:64])
+	if !ValidateSignatureValues(sig[64], r, s, false) {
+		return nil, ErrInvalidSignature
+	}
+
+	compact := make([]byte, SIGNATURE_LENGTH)
+	compact[0] = 27 + sig[64]
+	copy(compact[1:], sig[:64])
+	pub, _, err := ecdsa.RecoverCompact(compact, hash)
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+	return &PublicKey{X: pub.X(), Y: pub.Y()}, nil
+}
+
+// ValidateSignatureValues reports whether r, s and the recovery ID v form a
+// valid signature. With lowS, S must be in the lower half of the curve order,
+// as required of transaction signatures since Homestead (EIP-2).
+func ValidateSignatureValues(v byte, r, s *big.Int, lowS bool) bool {
+	if r.Sign() <= 0 || s.Sign() <= 0 || r.Cmp(secp256k1N) >= 0 || s.Cmp(secp256k1N) >= 0 {
+		return false
+	}
+	if lowS && s.Cmp(secp256k1HalfN) > 0 {
+		return false
+	}
+	return v <= 3
+}
@@ -0,0 +1,153 @@
+package crypto
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"testing"
+)
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestSignKnownAnswer(t *testing.T) {
+
+	// EIP-155 example: the signing hash of the example transaction, signed
+	// with the 0x46... key, gives v = 37, i.e. a recovery ID of 0
+	key, err := HexToPrivateKey("4646464646464646464646464646464646464646464646464646464646464646")
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash := mustDecodeHex(t, "daf5a779ae972f972197303d7b574746c7ef83eadac0f2791ad23db92e4c8e53")
+	r, _ := new(big.Int).SetString("18515461264373351373200002665853028612451056578545711640558177340181847433846", 10)
+	s, _ := new(big.Int).SetString("46948507304638947509940763649030358759909902576025900602547168820602576006531", 10)
+
+	sig, err := Sign(hash, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := new(big.Int).SetBytes(sig[:32]); got.Cmp(r) != 0 {
+		t.Errorf("r = %s, want %s", got, r)
+	}
+	if got := new(big.Int).SetBytes(sig[32:64]); got.Cmp(s) != 0 {
+		t.Errorf("s = %s, want %s", got, s)
+	}
+	if sig[64] != 0 {
+		t.Errorf("v = %d, want 0", sig[64])
+	}
+
+	// RFC 6979 nonces make signing deterministic
+	sig2, err := Sign(hash, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(sig, sig2) {
+		t.Errorf("signatures of the same hash differ: %x != %x", sig, sig2)
+	}
+}
+
+func TestRecoverPublicKeyKnownAnswer(t *testing.T) {
+
+	// go-ethereum crypto signature tests
+	hash := mustDecodeHex(t, "ce0677bb30baa8cf067c88db9811f4333d131bf8bcf12fe7065d211dce971008")
+	sig := mustDecodeHex(t, "90f27b8b488db00b00606796d2987f6a5f59ae62ea05effe84fef5b8b0e549984a691139ad57a3f0b906637673aa2f63d1f55cb1a69199d4009eea23ceaddc9301")
+	want := "04e32df42865e97135acfb65f3bae71bdc86f4d49150ad6a440b6f15878109880a0a2b2667f7e725ceea70c673093bf67663e0312623c8e091b13cf2c0f11ef652"
+
+	pub, err := RecoverPublicKey(hash, sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := hex.EncodeToString(pub.Bytes()); got != want {
+		t.Errorf("RecoverPublicKey = %s, want %s", got, want)
+	}
+}
+
+func TestSignRecover(t *testing.T) {
+
+	key, err := HexToPrivateKey("289c2857d4598e37fb9647507e47a309d6133539bf21a8b9cb6df88fd5232032")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 32; i++ {
+		hash := Keccak256([]byte{byte(i)})
+		sig, err := Sign(hash, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := new(big.Int).SetBytes(sig[:32])
+		s := new(big.Int).SetBytes(sig[32:64])
+		if !ValidateSignatureValues(sig[64], r, s, true) {
+			t.Errorf("signature %x is not canonical", sig)
+		}
+		pub, err := RecoverPublicKey(hash, sig)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(pub.Address(), key.Address()) {
+			t.Errorf("recovered address %x, want %x", pub.Address(), key.Address())
+		}
+	}
+}
+
+func TestRecoverPublicKeyHighS(t *testing.T) {
+
+	key, err := HexToPrivateKey("289c2857d4598e37fb9647507e47a309d6133539bf21a8b9cb6df88fd5232032")
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash := Keccak256([]byte("foo"))
+	sig, err := Sign(hash, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// (r, n - s) with the other recovery ID is the same signature, as found
+	// in pre-Homestead transactions
+	s := new(big.Int).SetBytes(sig[32:64])
+	s.Sub(secp256k1N, s)
+	s.FillBytes(sig[32:64])
+	sig[64] ^= 1
+
+	pub, err := RecoverPublicKey(hash, sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(pub.Address(), key.Address()) {
+		t.Errorf("recovered address %x, want %x", pub.Address(), key.Address())
+	}
+}
+
+func TestRecoverPublicKeyInvalid(t *testing.T) {
+
+	hash := Keccak256([]byte("foo"))
+	n := secp256k1N.FillBytes(make([]byte, 32))
+	valid := bytes.Repeat([]byte{0x01}, 32)
+
+	tests := []struct {
+		name string
+		sig  []byte
+	}{
+		{"zero r", append(append(make([]byte, 32), valid...), 0)},
+		{"zero s", append(append(append([]byte{}, valid...), make([]byte, 32)...), 0)},
+		{"r = n", append(append(append([]byte{}, n...), valid...), 0)},
+		{"s = n", append(append(append([]byte{}, valid...), n...), 0)},
+		{"recovery ID 4", append(append(append([]byte{}, valid...), valid...), 4)},
+	}
+	for _, test := range tests {
+		_, err := RecoverPublicKey(hash, test.sig)
+		if err != ErrInvalidSignature {
+			t.Errorf("%s: error = %v, want ErrInvalidSignature", test.name, err)
+		}
+	}
+
+	_, err := RecoverPublicKey(hash, make([]byte, 64))
+	if err == nil {
+		t.Errorf("64 byte signature: no error")
+	}
+}
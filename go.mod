@@ -0,0 +1,10 @@
+module github.com/INFURA/go-libs
+
+go 1.25.0
+
+require (
+	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.4.1
+	golang.org/x/crypto v0.54.0
+)
+
+require golang.org/x/sys v0.47.0 // indirect
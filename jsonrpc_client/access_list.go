@@ -0,0 +1,117 @@
+package jsonrpc_client
+
+import (
+	"encoding/json"
+	"math/big"
+)
+
+// AccessTuple is an address and the storage keys a transaction pre-declares
+// it will access (EIP-2930). It has the same JSON form in the JSON-RPC and
+// stored forms since it holds no quantities.
+type AccessTuple struct {
+	Address     Address `json:"address"`
+	StorageKeys []Hash  `json:"storageKeys"`
+}
+
+type AccessList []AccessTuple
+
+// Equals determines whether two AccessLists are equal
+func (accessList AccessList) Equals(accessList2 AccessList) bool {
+	if (accessList == nil) != (accessList2 == nil) ||
+		len(accessList) != len(accessList2) {
+		return false
+	}
+	for i := range accessList {
+		if accessList[i].Address != accessList2[i].Address ||
+			!AreEqualHashSlice(accessList[i].StorageKeys, accessList2[i].StorageKeys) {
+			return false
+		}
+	}
+	return true
+}
+
+// copy returns a deep copy of the AccessList, preserving nil
+func (accessList AccessList) copy() AccessList {
+	if accessList == nil {
+		return nil
+	}
+	accessListCopy := make(AccessList, len(accessList))
+	for i, tuple := range accessList {
+		accessListCopy[i] = AccessTuple{
+			Address:     tuple.Address,
+			StorageKeys: append([]Hash{}, tuple.StorageKeys...),
+		}
+	}
+	return accessListCopy
+}
+
+// Authorization is a signed delegation of an account's code to a contract (EIP-7702)
+type Authorization struct {
+	ChainId *big.Int `json:"chain_id"`
+	Address Address  `json:"address"`
+	Nonce   uint64   `json:"nonce"`
+	YParity uint64   `json:"y_parity"`
+	R       *big.Int `json:"r"`
+	S       *big.Int `json:"s"`
+}
+
+// authorizationJSON is the JSON-RPC form of an Authorization
+type authorizationJSON struct {
+	ChainId *HexBig   `json:"chainId"`
+	Address Address   `json:"address"`
+	Nonce   HexUint64 `json:"nonce"`
+	YParity HexUint64 `json:"yParity"`
+	R       *HexBig   `json:"r"`
+	S       *HexBig   `json:"s"`
+}
+
+// storedAuthorization is the snake_case form of an Authorization
+type storedAuthorization Authorization
+
+// MarshalJSON encodes the authorization in the JSON-RPC form
+func (auth Authorization) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&authorizationJSON{
+		ChainId: NewHexBig(auth.ChainId),
+		Address: auth.Address,
+		Nonce:   HexUint64(auth.Nonce),
+		YParity: HexUint64(auth.YParity),
+		R:       NewHexBig(auth.R),
+		S:       NewHexBig(auth.S),
+	})
+}
+
+// UnmarshalJSON decodes an authorization in the JSON-RPC form
+func (auth *Authorization) UnmarshalJSON(b []byte) error {
+	var dec authorizationJSON
+	err := json.Unmarshal(b, &dec)
+	if err != nil {
+		return err
+	}
+	*auth = Authorization{
+		ChainId: dec.ChainId.ToInt(),
+		Address: dec.Address,
+		Nonce:   uint64(dec.Nonce),
+		YParity: uint64(dec.YParity),
+		R:       dec.R.ToInt(),
+		S:       dec.S.ToInt(),
+	}
+	return nil
+}
+
+// AreEqualAuthorizations
+func AreEqualAuthorizations(a, b []Authorization) bool {
+	if (a == nil) != (b == nil) || len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !AreEqualBigInt(a[i].ChainId, b[i].ChainId) ||
+			a[i].Address != b[i].Address ||
+			a[i].Nonce != b[i].Nonce ||
+			a[i].YParity != b[i].YParity ||
+			!AreEqualBigInt(a[i].R, b[i].R) ||
+			!AreEqualBigInt(a[i].S, b[i].S) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,88 @@
+package jsonrpc_client
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+)
+
+// AccountState is the state of an account at a block
+type AccountState struct {
+	Address Address
+	Balance *big.Int // in wei
+	Nonce   uint64
+	Code    []byte // empty for accounts without code
+	Storage map[Hash]Hash
+}
+
+// GetAccountState fetches the balance, nonce, code and the given storage
+// slots of address at the given block using a single batch
+func (client *EthereumClient) GetAccountState(address Address, block BlockNumberOrTag, slots ...Hash) (*AccountState, error) {
+	return client.GetAccountStateContext(context.Background(), address, block, slots...)
+}
+
+// GetAccountStateContext fetches the balance, nonce, code and the given
+// storage slots of address at the given block using a single batch with the
+// given context
+func (client *EthereumClient) GetAccountStateContext(ctx context.Context, address Address, block BlockNumberOrTag, slots ...Hash) (*AccountState, error) {
+
+	var balance HexBig
+	var nonce HexUint64
+	var code HexBytes
+	values := make([]Hash, len(slots))
+
+	elems := []*BatchElem{
+		{
+			Request: JSONRPCRequest{
+				Method: "eth_getBalance",
+				Params: []interface{}{address, block},
+			},
+			Result: &balance,
+		},
+		{
+			Request: JSONRPCRequest{
+				Method: "eth_getTransactionCount",
+				Params: []interface{}{address, block},
+			},
+			Result: &nonce,
+		},
+		{
+			Request: JSONRPCRequest{
+				Method: "eth_getCode",
+				Params: []interface{}{address, block},
+			},
+			Result: &code,
+		},
+	}
+	for i, slot := range slots {
+		elems = append(elems, &BatchElem{
+			Request: JSONRPCRequest{
+				Method: "eth_getStorageAt",
+				Params: []interface{}{address, slot, block},
+			},
+			Result: &values[i],
+		})
+	}
+
+	err := client.BatchRequestContext(ctx, elems)
+	if err != nil {
+		return nil, err
+	}
+	for _, elem := range elems {
+		if elem.Error != nil {
+			return nil, fmt.Errorf("GetAccountState %s: %w", elem.Request.Method, elem.Error)
+		}
+	}
+
+	state := &AccountState{
+		Address: address,
+		Balance: balance.ToInt(),
+		Nonce:   uint64(nonce),
+		Code:    code,
+		Storage: make(map[Hash]Hash, len(slots)),
+	}
+	for i, slot := range slots {
+		state.Storage[slot] = values[i]
+	}
+	return state, nil
+}
@@ -0,0 +1,98 @@
+package jsonrpc_client
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// accountStateHandler serves the state of a single contract at block 0x10.
+// Storage slots hold their last byte followed by 0xff.
+func accountStateHandler(method string, params []json.RawMessage) (interface{}, error) {
+	if string(params[len(params)-1]) != `"0x10"` {
+		return nil, &RPCError{Code: SERVER_ERROR_CODE, Message: "header not found"}
+	}
+	switch method {
+	case "eth_getBalance":
+		// more than 64 bits
+		return "0x3635c9adc5dea00000", nil
+	case "eth_getTransactionCount":
+		return "0x7", nil
+	case "eth_getCode":
+		return "0x6080604052", nil
+	case "eth_getStorageAt":
+		var slot Hash
+		json.Unmarshal(params[1], &slot)
+		return BytesToHash(append(slot[31:], 0xff)), nil
+	}
+	return nil, &RPCError{Code: METHOD_NOT_FOUND_CODE, Message: "method not found"}
+}
+
+func TestClientAccountState(t *testing.T) {
+
+	server := newRPCServer(t, accountStateHandler)
+	client := NewEthereumClient(server.URL)
+	address := BytesToAddress([]byte{0x35})
+	block := BlockAtNumber(0x10)
+
+	balance, err := client.Eth_getBalance(address, block)
+	want, _ := new(big.Int).SetString("1000000000000000000000", 10)
+	if err != nil || balance.Cmp(want) != 0 {
+		t.Errorf("Eth_getBalance() = %v, %v, want %v", balance, err, want)
+	}
+	nonce, err := client.Eth_getTransactionCount(address, block)
+	if err != nil || nonce != 7 {
+		t.Errorf("Eth_getTransactionCount() = %d, %v", nonce, err)
+	}
+	code, err := client.Eth_getCode(address, block)
+	if err != nil || !HexBytes(code).Equal(HexBytes{0x60, 0x80, 0x60, 0x40, 0x52}) {
+		t.Errorf("Eth_getCode() = %x, %v", code, err)
+	}
+	value, err := client.Eth_getStorageAt(address, Hash{31: 2}, block)
+	if err != nil || value != (Hash{30: 2, 31: 0xff}) {
+		t.Errorf("Eth_getStorageAt() = %v, %v", value, err)
+	}
+
+	_, err = client.Eth_getBalance(address, LatestBlock)
+	if !IsHeaderNotFound(err) {
+		t.Errorf("Eth_getBalance(latest) = %v, want header not found", err)
+	}
+}
+
+func TestClientGetAccountState(t *testing.T) {
+
+	server := newRPCServer(t, accountStateHandler)
+	transport := &countingTransport{}
+	client := NewEthereumClient(server.URL, WithHTTPClient(&http.Client{Transport: transport}))
+	address := BytesToAddress([]byte{0x35})
+
+	state, err := client.GetAccountState(address, BlockAtNumber(0x10), Hash{31: 1}, Hash{31: 2})
+	if err != nil {
+		t.Fatalf("GetAccountState(): %v", err)
+	}
+	// all four methods share one batch
+	if n := atomic.LoadInt32(&transport.requests); n != 1 {
+		t.Errorf("GetAccountState() made %d requests, want 1", n)
+	}
+	if state.Address != address || state.Nonce != 7 || len(state.Code) != 5 || state.Balance.BitLen() <= 64 {
+		t.Errorf("GetAccountState() = %+v", state)
+	}
+	if len(state.Storage) != 2 || state.Storage[Hash{31: 1}] != (Hash{30: 1, 31: 0xff}) || state.Storage[Hash{31: 2}] != (Hash{30: 2, 31: 0xff}) {
+		t.Errorf("Storage = %v", state.Storage)
+	}
+
+	// without slots no storage is requested
+	state, err = client.GetAccountState(address, BlockAtNumber(0x10))
+	if err != nil || len(state.Storage) != 0 {
+		t.Errorf("GetAccountState(no slots) = %+v, %v", state, err)
+	}
+
+	// a failing request fails the whole state, naming the method
+	_, err = client.GetAccountState(address, FinalizedBlock)
+	if !IsHeaderNotFound(err) || !strings.Contains(err.Error(), "eth_getBalance") {
+		t.Errorf("GetAccountState(finalized) = %v, want header not found", err)
+	}
+}
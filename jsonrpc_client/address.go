@@ -0,0 +1,110 @@
+package jsonrpc_client
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/INFURA/go-libs/crypto"
+)
+
+// ADDRESS_LENGTH is the length of an account address in bytes
+const ADDRESS_LENGTH = 20
+
+// Address is a 20 byte account address. It is encoded in JSON as a lowercase
+// 0x-prefixed hex string, printed with its EIP-55 checksum, and can be
+// compared with == and used as a map key.
+type Address [ADDRESS_LENGTH]byte
+
+// ParseAddress parses a 0x-prefixed hex string of exactly 20 bytes. Mixed
+// case strings must carry a valid EIP-55 checksum.
+func ParseAddress(s string) (Address, error) {
+	var address Address
+	err := decodeFixedHex(address[:], s, "address")
+	if err != nil {
+		return address, err
+	}
+	digits := s[2:]
+	if digits != strings.ToLower(digits) && digits != strings.ToUpper(digits) &&
+		digits != address.Checksum()[2:] {
+		return address, fmt.Errorf("address %q has an invalid EIP-55 checksum", s)
+	}
+	return address, nil
+}
+
+// BytesToAddress returns the Address of b, keeping its last 20 bytes if it
+// is longer and left padding it with zeros if it is shorter
+func BytesToAddress(b []byte) Address {
+	var address Address
+	if len(b) > ADDRESS_LENGTH {
+		b = b[len(b)-ADDRESS_LENGTH:]
+	}
+	copy(address[ADDRESS_LENGTH-len(b):], b)
+	return address
+}
+
+// IsChecksumAddress reports whether s is an address with a valid EIP-55
+// checksum
+func IsChecksumAddress(s string) bool {
+	address, err := ParseAddress(s)
+	return err == nil && s == address.Checksum()
+}
+
+// Bytes returns the bytes of the address
+func (address Address) Bytes() []byte {
+	return address[:]
+}
+
+// Hex returns the lowercase 0x-prefixed hex encoding
+func (address Address) Hex() string {
+	return "0x" + hex.EncodeToString(address[:])
+}
+
+// Checksum returns the EIP-55 mixed case encoding: each letter is upper
+// case if the matching nibble of the keccak256 hash of the lowercase hex
+// is 8 or more
+func (address Address) Checksum() string {
+	digits := []byte(hex.EncodeToString(address[:]))
+	hash := crypto.Keccak256(digits)
+	for i, c := range digits {
+		nibble := hash[i/2] >> 4
+		if i%2 == 1 {
+			nibble = hash[i/2] & 0x0f
+		}
+		if c >= 'a' && nibble >= 8 {
+			digits[i] = c - 'a' + 'A'
+		}
+	}
+	return "0x" + string(digits)
+}
+
+// String returns the EIP-55 checksum encoding
+func (address Address) String() string {
+	return address.Checksum()
+}
+
+// IsZero reports whether all bytes of the address are zero
+func (address Address) IsZero() bool {
+	return address == Address{}
+}
+
+// Cmp compares two addresses as big-endian numbers, returning -1, 0 or +1
+func (address Address) Cmp(address2 Address) int {
+	return bytes.Compare(address[:], address2[:])
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (address Address) MarshalText() ([]byte, error) {
+	return []byte(address.Hex()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (address *Address) UnmarshalText(text []byte) error {
+	parsed, err := ParseAddress(string(text))
+	if err != nil {
+		return err
+	}
+	*address = parsed
+	return nil
+}
@@ -0,0 +1,119 @@
+package jsonrpc_client
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// TestAddressChecksum checks the test vectors of EIP-55
+func TestAddressChecksum(t *testing.T) {
+	vectors := []string{
+		// all caps
+		"0x52908400098527886E0F7030069857D2E4169EE7",
+		"0x8617E340B3D01FA5F11F306F4090FD50E238070D",
+		// all lower
+		"0xde709f2102306220921060314715629080e2fb77",
+		"0x27b1fdb04752bbc536007a920d24acb045561c26",
+		// normal
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+		"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+		"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb",
+	}
+	for _, vector := range vectors {
+		address, err := ParseAddress(vector)
+		if err != nil {
+			t.Errorf("ParseAddress(%s): %v", vector, err)
+			continue
+		}
+		if address.Checksum() != vector || address.String() != vector {
+			t.Errorf("Checksum() = %s, want %s", address.Checksum(), vector)
+		}
+		if !IsChecksumAddress(vector) {
+			t.Errorf("IsChecksumAddress(%s) = false", vector)
+		}
+		if address.Hex() != strings.ToLower(vector) {
+			t.Errorf("Hex() = %s, want lower case", address.Hex())
+		}
+	}
+
+	// flipping the case of one letter breaks the checksum
+	_, err := ParseAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD")
+	if err == nil || !strings.Contains(err.Error(), "EIP-55") {
+		t.Errorf("ParseAddress() = %v, want a checksum error", err)
+	}
+	// single case addresses are accepted but carry no checksum
+	if _, err := ParseAddress("0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"); err != nil {
+		t.Errorf("ParseAddress(lower case): %v", err)
+	}
+	if IsChecksumAddress("0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed") {
+		t.Errorf("IsChecksumAddress() accepted a lower case address whose checksum has upper case letters")
+	}
+}
+
+func TestParseAddress(t *testing.T) {
+
+	invalid := []string{
+		"",
+		"0x",
+		"5aaeb6053f3e94c9b9a09f33669435e7ef1beaed",
+		"0x5aaeb6053f3e94c9b9a09f33669435e7ef1bea",
+		"0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed00",
+		"0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaeg",
+	}
+	for _, s := range invalid {
+		if _, err := ParseAddress(s); err == nil {
+			t.Errorf("ParseAddress(%q) accepted an invalid address", s)
+		}
+	}
+
+	if BytesToAddress([]byte{1, 2}) != (Address{18: 1, 19: 2}) {
+		t.Errorf("BytesToAddress() doesn't left pad")
+	}
+	long := make([]byte, 32)
+	long[31] = 7
+	if BytesToAddress(long) != (Address{19: 7}) {
+		t.Errorf("BytesToAddress() doesn't keep the last 20 bytes")
+	}
+	if !(Address{}).IsZero() || (Address{19: 1}).IsZero() {
+		t.Errorf("IsZero() is wrong")
+	}
+	if (Address{19: 1}).Cmp(Address{0: 1}) != -1 || (Address{1}).Cmp(Address{1}) != 0 {
+		t.Errorf("Cmp() doesn't compare as big-endian numbers")
+	}
+}
+
+func TestAddressJSON(t *testing.T) {
+
+	// addresses are encoded in lower case, and case doesn't matter for
+	// equality or map keys
+	var decoded struct {
+		Upper Address  `json:"upper"`
+		Lower Address  `json:"lower"`
+		Null  *Address `json:"null"`
+	}
+	err := json.Unmarshal([]byte(`{"upper":"0x52908400098527886E0F7030069857D2E4169EE7","lower":"0x52908400098527886e0f7030069857d2e4169ee7","null":null}`), &decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Upper != decoded.Lower || decoded.Null != nil {
+		t.Errorf("Unmarshal() = %+v", decoded)
+	}
+	seen := map[Address]bool{decoded.Upper: true}
+	if !seen[decoded.Lower] {
+		t.Errorf("addresses differing in case are different map keys")
+	}
+
+	b, err := json.Marshal(decoded.Upper)
+	if err != nil || string(b) != `"0x52908400098527886e0f7030069857d2e4169ee7"` {
+		t.Errorf("Marshal() = %s, %v", b, err)
+	}
+
+	for _, invalid := range []string{`"0x1234"`, `1234`, `"52908400098527886e0f7030069857d2e4169ee7"`} {
+		var address Address
+		if err := json.Unmarshal([]byte(invalid), &address); err == nil {
+			t.Errorf("Unmarshal(%s) accepted an invalid address", invalid)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package jsonrpc_client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// BatchElem is a single request within a JSON-RPC batch
+type BatchElem struct {
+	Request JSONRPCRequest
+	// Result, when non-nil, receives the unmarshalled result of the request
+	Result interface{}
+	// Error is set when the node returned an error for this request, when
+	// the result could not be unmarshalled, or when no response was returned
+	Error error
+
+	// check, when set, checks the result in strict decoding mode
+	check valueCheck
+}
+
+// BatchRequest sends elems as a single JSON-RPC batch
+func (client *EthereumClient) BatchRequest(elems []*BatchElem) error {
+	return client.BatchRequestContext(context.Background(), elems)
+}
+
+// BatchRequestContext sends elems as a single JSON-RPC batch with the given
+// context. Every request is assigned a unique ID and the responses are matched
+// back by ID, so the node may reply in any order. The returned error is only
+// set when the batch as a whole failed; per-request failures are reported
+// through each BatchElem's Error.
+func (client *EthereumClient) BatchRequestContext(ctx context.Context, elems []*BatchElem) error {
+
+	// an empty batch is an invalid JSON-RPC request
+	if len(elems) == 0 {
+		return nil
+	}
+
+	byID := make(map[int64]*BatchElem, len(elems))
+	ids := make([]int64, len(elems))
+	methods := make([]string, len(elems))
+	reqs := make([]*JSONRPCRequest, len(elems))
+	for i, elem := range elems {
+		elem.Request.JSONRPC = "2.0"
+		elem.Request.ID = client.nextID()
+		elem.Error = nil
+		byID[elem.Request.ID] = elem
+		ids[i] = elem.Request.ID
+		methods[i] = elem.Request.Method
+		reqs[i] = &elem.Request
+	}
+
+	payload, err := json.Marshal(reqs)
+	if err != nil {
+		return err
+	}
+
+	body, err := client.roundTrip(ctx, payload, ids, methods)
+	if err != nil {
+		return err
+	}
+
+	// a node rejecting the batch as a whole replies with a single object
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '{' {
+		var respBase ResponseBase
+		err = json.Unmarshal(body, &respBase)
+		if err != nil {
+			return err
+		}
+		if respBase.Error != nil {
+			return respBase.Error
+		}
+		return fmt.Errorf("BatchRequest: expected an array of responses")
+	}
+
+	var resps []RawResponse
+	err = json.Unmarshal(body, &resps)
+	if err != nil {
+		return err
+	}
+
+	answered := make(map[int64]bool, len(resps))
+	for _, resp := range resps {
+		elem, ok := byID[resp.ID]
+		if !ok || answered[resp.ID] {
+			continue
+		}
+		answered[resp.ID] = true
+
+		err = resp.validate(resp.ID)
+		if err != nil {
+			elem.Error = err
+			continue
+		}
+		if elem.check != nil && client.decodeMode == StrictDecoding {
+			err = checkValue(resp.Result, "result", elem.check)
+			if err != nil {
+				elem.Error = err
+				continue
+			}
+		}
+		if elem.Result != nil {
+			err = json.Unmarshal(resp.Result, elem.Result)
+			if err != nil {
+				elem.Error = err
+			}
+		}
+	}
+
+	for id, elem := range byID {
+		if !answered[id] {
+			elem.Error = fmt.Errorf("BatchRequest: no response for request %d", id)
+		}
+	}
+
+	return nil
+}
+
+// GetBlockRange fetches the blocks numbered from to to, inclusive, using a
+// single eth_getBlockByNumber batch
+func (client *EthereumClient) GetBlockRange(from, to uint64, full bool) ([]*Block, error) {
+	return client.GetBlockRangeContext(context.Background(), from, to, full)
+}
+
+// GetBlockRangeContext fetches the blocks numbered from to to, inclusive,
+// using a single eth_getBlockByNumber batch with the given context
+func (client *EthereumClient) GetBlockRangeContext(ctx context.Context, from, to uint64, full bool) ([]*Block, error) {
+
+	if to < from {
+		return nil, fmt.Errorf("GetBlockRange: invalid range %d-%d", from, to)
+	}
+
+	numBlocks := to - from + 1
+	elems := make([]*BatchElem, numBlocks)
+	blocks := make([]*Block, numBlocks)
+	for i := range elems {
+		elems[i] = &BatchElem{
+			Request: JSONRPCRequest{
+				Method: "eth_getBlockByNumber",
+				Params: []interface{}{BlockAtNumber(from + uint64(i)), full},
+			},
+			Result: &blocks[i],
+			check:  checkBlock,
+		}
+	}
+
+	err := client.BatchRequestContext(ctx, elems)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, elem := range elems {
+		if elem.Error != nil {
+			return nil, fmt.Errorf("GetBlockRange block %d: %w", from+uint64(i), elem.Error)
+		}
+		// the node returns null for blocks it doesn't have
+		if blocks[i] == nil {
+			return nil, fmt.Errorf("GetBlockRange block %d: not found", from+uint64(i))
+		}
+	}
+
+	return blocks, nil
+}
@@ -0,0 +1,152 @@
+package jsonrpc_client
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestBatchRequest(t *testing.T) {
+
+	// the node replies in reverse order, leaves out eth_dropped and answers
+	// eth_mismatch with another ID
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		var reqs []testRequest
+		err := json.Unmarshal(body, &reqs)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var resps []testResponse
+		for i := len(reqs) - 1; i >= 0; i-- {
+			resp := testResponse{JSONRPC: "2.0", ID: reqs[i].ID}
+			switch reqs[i].Method {
+			case "eth_chainId":
+				resp.Result = "0x1"
+			case "eth_gasPrice":
+				resp.Result = "not a quantity"
+			case "eth_mismatch":
+				resp.ID = json.RawMessage("123456")
+				resp.Result = "0x1"
+			case "eth_dropped":
+				continue
+			default:
+				resp.Error = &RPCError{Code: METHOD_NOT_FOUND_CODE, Message: "method not found"}
+			}
+			resps = append(resps, resp)
+		}
+		json.NewEncoder(w).Encode(resps)
+	}))
+	defer server.Close()
+	client := NewEthereumClient(server.URL)
+
+	var chainID, gasPrice HexUint64
+	elems := []*BatchElem{
+		{Request: JSONRPCRequest{Method: "eth_chainId"}, Result: &chainID},
+		{Request: JSONRPCRequest{Method: "eth_gasPrice"}, Result: &gasPrice},
+		{Request: JSONRPCRequest{Method: "eth_unknown"}},
+		{Request: JSONRPCRequest{Method: "eth_dropped"}},
+		{Request: JSONRPCRequest{Method: "eth_mismatch"}},
+	}
+	err := client.BatchRequest(elems)
+	if err != nil {
+		t.Fatalf("BatchRequest(): %v", err)
+	}
+
+	for i, elem := range elems {
+		for _, other := range elems[:i] {
+			if elem.Request.ID == other.Request.ID {
+				t.Errorf("requests %s and %s share ID %d", other.Request.Method, elem.Request.Method, elem.Request.ID)
+			}
+		}
+	}
+	if elems[0].Error != nil || chainID != 1 {
+		t.Errorf("eth_chainId = %d, %v, want 1", chainID, elems[0].Error)
+	}
+	if elems[1].Error == nil {
+		t.Errorf("eth_gasPrice accepted an invalid result")
+	}
+	if !IsMethodNotFound(elems[2].Error) {
+		t.Errorf("eth_unknown error = %v, want method not found", elems[2].Error)
+	}
+	for _, elem := range elems[3:] {
+		if elem.Error == nil || !strings.Contains(elem.Error.Error(), "no response") {
+			t.Errorf("%s error = %v, want no response", elem.Request.Method, elem.Error)
+		}
+	}
+}
+
+func TestBatchRequestRejected(t *testing.T) {
+
+	// a node rejecting the batch replies with a single error object
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"jsonrpc":"2.0","id":null,"error":{"code":-32005,"message":"batch too large"}}`))
+	}))
+	defer server.Close()
+	client := NewEthereumClient(server.URL)
+
+	err := client.BatchRequest([]*BatchElem{{Request: JSONRPCRequest{Method: "eth_chainId"}}})
+	var rpcErr *RPCError
+	if !errors.As(err, &rpcErr) || !IsRateLimited(err) {
+		t.Errorf("BatchRequest() = %v, want the batch error", err)
+	}
+
+	// an empty batch is not sent
+	err = client.BatchRequest(nil)
+	if err != nil {
+		t.Errorf("BatchRequest(nil) = %v", err)
+	}
+}
+
+func TestGetBlockRange(t *testing.T) {
+
+	requests := 0
+	server := newRPCServer(t, func(method string, params []json.RawMessage) (interface{}, error) {
+		requests++
+		var number string
+		json.Unmarshal(params[0], &number)
+		switch number {
+		case "0x0":
+			return json.RawMessage(mainnetGenesis), nil
+		case "0x1":
+			return json.RawMessage(mainnetBlock1), nil
+		case "0x2":
+			return nil, &RPCError{Code: SERVER_ERROR_CODE, Message: "header not found"}
+		}
+		// nodes return null for blocks they don't have
+		return json.RawMessage("null"), nil
+	})
+	client := NewEthereumClient(server.URL)
+
+	blocks, err := client.GetBlockRange(0, 1, false)
+	if err != nil {
+		t.Fatalf("GetBlockRange(0, 1): %v", err)
+	}
+	if len(blocks) != 2 || blocks[0].Number != 0 || blocks[1].Number != 1 {
+		t.Fatalf("GetBlockRange(0, 1) returned the wrong blocks")
+	}
+	if err := blocks[1].VerifyParent(blocks[0]); err != nil {
+		t.Errorf("VerifyParent(): %v", err)
+	}
+
+	// the failing block is named and its error kept
+	_, err = client.GetBlockRange(1, 2, false)
+	if !IsHeaderNotFound(err) || !strings.Contains(err.Error(), "block 2") {
+		t.Errorf("GetBlockRange(1, 2) = %v, want header not found for block 2", err)
+	}
+	_, err = client.GetBlockRange(3, 3, false)
+	if err == nil || !strings.Contains(err.Error(), "block 3: not found") {
+		t.Errorf("GetBlockRange(3, 3) = %v, want not found", err)
+	}
+
+	requests = 0
+	_, err = client.GetBlockRange(2, 1, false)
+	if err == nil || requests != 0 {
+		t.Errorf("GetBlockRange(2, 1) = %v after %d requests, want an invalid range error", err, requests)
+	}
+}
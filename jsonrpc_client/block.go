@@ -1,102 +1,391 @@
 package jsonrpc_client
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"math/big"
-	"strconv"
 )
 
+// Block is a block as returned by eth_getBlockByHash and
+// eth_getBlockByNumber. It is encoded in JSON in the JSON-RPC form, and in
+// its snake_case stored form by ToJSON and NewBlockFromJSON.
 type Block struct {
-	Author           string        `json:"author"`
-	Difficulty       int64         `json:"difficulty"`
-	ExtraData        string        `json:"extra_data"`
-	GasLimit         int           `json:"gas_limit"`
-	GasUsed          int           `json:"gas_used"`
-	Hash             string        `json:"hash"`
-	LogsBloom        string        `json:"logs_bloom"`
-	Miner            string        `json:"miner"`
-	MixHash          string        `json:"mix_hash"`
+	Author           *Address      `json:"author"`
+	Difficulty       *big.Int      `json:"difficulty"`
+	ExtraData        HexBytes      `json:"extra_data"`
+	GasLimit         uint64        `json:"gas_limit"`
+	GasUsed          uint64        `json:"gas_used"`
+	Hash             Hash          `json:"hash"`
+	LogsBloom        Bloom         `json:"logs_bloom"`
+	Miner            Address       `json:"miner"`
+	MixHash          Hash          `json:"mix_hash"`
 	Nonce            *big.Int      `json:"nonce"`
-	Number           int           `json:"number"`
-	ParentHash       string        `json:"parent_hash"`
-	ReceiptsRoot     string        `json:"receipts_root"`
-	SealFields       []string      `json:"seal_fields"`
-	SHA3Uncles       string        `json:"sha3_uncles"`
-	Size             int           `json:"size"`
-	StateRoot        string        `json:"state_root"`
-	Timestamp        int           `json:"timestamp"`
+	Number           uint64        `json:"number"`
+	ParentHash       Hash          `json:"parent_hash"`
+	ReceiptsRoot     Hash          `json:"receipts_root"`
+	SealFields       []HexBytes    `json:"seal_fields"`
+	SHA3Uncles       Hash          `json:"sha3_uncles"`
+	Size             uint64        `json:"size"`
+	StateRoot        Hash          `json:"state_root"`
+	Timestamp        uint64        `json:"timestamp"`
 	TotalDifficulty  *big.Int      `json:"total_difficulty"`
 	Transactions     []Transaction `json:"transactions"`
-	TransactionsRoot string        `json:"transactions_root"`
-	Uncles           []string      `json:"uncles"`
+	TransactionsRoot Hash          `json:"transactions_root"`
+	Uncles           []Hash        `json:"uncles"`
+
+	// TransactionHashes is set instead of Transactions when the block was
+	// fetched without full transactions
+	TransactionHashes []Hash `json:"transaction_hashes,omitempty"`
+
+	// London (EIP-1559)
+	BaseFeePerGas *big.Int `json:"base_fee_per_gas"`
+
+	// Shanghai (EIP-4895)
+	WithdrawalsRoot *Hash        `json:"withdrawals_root"`
+	Withdrawals     []Withdrawal `json:"withdrawals"`
+
+	// Cancun (EIP-4844, EIP-4788)
+	BlobGasUsed           *uint64 `json:"blob_gas_used"`
+	ExcessBlobGas         *uint64 `json:"excess_blob_gas"`
+	ParentBeaconBlockRoot *Hash   `json:"parent_beacon_block_root"`
+
+	// Prague (EIP-7685)
+	RequestsHash *Hash `json:"requests_hash"`
 }
 
-func NewBlockFromJSON(b []byte) (*Block, error) {
-	block := Block{}
-	err := json.Unmarshal(b, &block)
+// blockJSON is the JSON-RPC form of a Block
+type blockJSON struct {
+	Author           *Address          `json:"author"` // Parity only
+	Difficulty       *HexBig           `json:"difficulty"`
+	ExtraData        HexBytes          `json:"extraData"`
+	GasLimit         HexUint64         `json:"gasLimit"`
+	GasUsed          HexUint64         `json:"gasUsed"`
+	Hash             Hash              `json:"hash"`
+	LogsBloom        Bloom             `json:"logsBloom"`
+	Miner            Address           `json:"miner"`
+	MixHash          Hash              `json:"mixHash"`
+	Nonce            HexBytes          `json:"nonce"` // 8 bytes, not a quantity
+	Number           HexUint64         `json:"number"`
+	ParentHash       Hash              `json:"parentHash"`
+	ReceiptsRoot     Hash              `json:"receiptsRoot"`
+	SealFields       []HexBytes        `json:"sealFields"` // Parity only
+	SHA3Uncles       Hash              `json:"sha3Uncles"`
+	Size             HexUint64         `json:"size"` // absent from newHeads notifications
+	StateRoot        Hash              `json:"stateRoot"`
+	Timestamp        HexUint64         `json:"timestamp"`
+	TotalDifficulty  *HexBig           `json:"totalDifficulty"`
+	Transactions     blockTransactions `json:"transactions"`
+	TransactionsRoot Hash              `json:"transactionsRoot"`
+	Uncles           []Hash            `json:"uncles"`
+
+	// London (EIP-1559)
+	BaseFeePerGas *HexBig `json:"baseFeePerGas"`
+
+	// Shanghai (EIP-4895)
+	WithdrawalsRoot *Hash        `json:"withdrawalsRoot"`
+	Withdrawals     []Withdrawal `json:"withdrawals"`
+
+	// Cancun (EIP-4844, EIP-4788)
+	BlobGasUsed           *HexUint64 `json:"blobGasUsed"`
+	ExcessBlobGas         *HexUint64 `json:"excessBlobGas"`
+	ParentBeaconBlockRoot *Hash      `json:"parentBeaconBlockRoot"`
+
+	// Prague (EIP-7685)
+	RequestsHash *Hash `json:"requestsHash"`
+}
+
+// blockTransactions holds the transactions of a block in the JSON-RPC form:
+// full transaction objects, or hashes when fetched without full transactions
+type blockTransactions struct {
+	full   []Transaction
+	hashes []Hash
+}
+
+// MarshalJSON implements json.Marshaler
+func (txs blockTransactions) MarshalJSON() ([]byte, error) {
+	if txs.full == nil && txs.hashes != nil {
+		return json.Marshal(txs.hashes)
+	}
+	if txs.full == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(txs.full)
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (txs *blockTransactions) UnmarshalJSON(b []byte) error {
+
+	var items []json.RawMessage
+	err := json.Unmarshal(b, &items)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	return &block, nil
+
+	*txs = blockTransactions{}
+	if len(items) > 0 && bytes.HasPrefix(bytes.TrimSpace(items[0]), []byte(`"`)) {
+		return json.Unmarshal(b, &txs.hashes)
+	}
+	txs.full = make([]Transaction, len(items))
+	for i, item := range items {
+		err = json.Unmarshal(item, &txs.full[i])
+		if err != nil {
+			return fmt.Errorf("transactions[%d]: %v", i, err)
+		}
+	}
+	return nil
 }
 
-// ToBlockResult
-func (block *Block) ToBlockResult() (*BlockResult, error) {
+// MarshalJSON encodes the block in the JSON-RPC form
+func (block Block) MarshalJSON() ([]byte, error) {
 
-	difficulty := "0x" + strconv.FormatInt(block.Difficulty, 16)
-	gasLimit := "0x" + strconv.FormatInt(int64(block.GasLimit), 16)
-	gasUsed := "0x" + strconv.FormatInt(int64(block.GasUsed), 16)
 	// the nonce must display the full 64 bits (16 hex characters)
-	nonce := "0x" + ZeroPad(block.Nonce.Text(16), 16)
-	number := "0x" + strconv.FormatInt(int64(block.Number), 16)
-	size := "0x" + strconv.FormatInt(int64(block.Size), 16)
-	timestamp := "0x" + strconv.FormatInt(int64(block.Timestamp), 16)
-	totalDifficulty := "0x" + block.TotalDifficulty.Text(16)
-
-	blockResult := BlockResult{
-		Author:          block.Author,
-		Difficulty:      difficulty,
-		ExtraData:       block.ExtraData,
-		GasLimit:        gasLimit,
-		GasUsed:         gasUsed,
-		Hash:            block.Hash,
-		LogsBloom:       block.LogsBloom,
-		Miner:           block.Miner,
-		MixHash:         block.MixHash,
-		Nonce:           nonce,
-		Number:          number,
-		ParentHash:      block.ParentHash,
-		ReceiptsRoot:    block.ReceiptsRoot,
-		SealFields:      block.SealFields,
-		SHA3Uncles:      block.SHA3Uncles,
-		Size:            size,
-		StateRoot:       block.StateRoot,
-		Timestamp:       timestamp,
-		TotalDifficulty: totalDifficulty,
-		// Transactions
+	var nonce HexBytes
+	if block.Nonce != nil {
+		if block.Nonce.Sign() < 0 || block.Nonce.BitLen() > 64 {
+			return nil, fmt.Errorf("Block Nonce: invalid value %v", block.Nonce)
+		}
+		nonce = block.Nonce.FillBytes(make([]byte, 8))
+	}
+
+	enc := blockJSON{
+		Author:           block.Author,
+		Difficulty:       NewHexBig(block.Difficulty),
+		ExtraData:        block.ExtraData,
+		GasLimit:         HexUint64(block.GasLimit),
+		GasUsed:          HexUint64(block.GasUsed),
+		Hash:             block.Hash,
+		LogsBloom:        block.LogsBloom,
+		Miner:            block.Miner,
+		MixHash:          block.MixHash,
+		Nonce:            nonce,
+		Number:           HexUint64(block.Number),
+		ParentHash:       block.ParentHash,
+		ReceiptsRoot:     block.ReceiptsRoot,
+		SealFields:       block.SealFields,
+		SHA3Uncles:       block.SHA3Uncles,
+		Size:             HexUint64(block.Size),
+		StateRoot:        block.StateRoot,
+		Timestamp:        HexUint64(block.Timestamp),
+		TotalDifficulty:  NewHexBig(block.TotalDifficulty),
+		Transactions:     blockTransactions{full: block.Transactions, hashes: block.TransactionHashes},
 		TransactionsRoot: block.TransactionsRoot,
 		Uncles:           block.Uncles,
+
+		BaseFeePerGas:         NewHexBig(block.BaseFeePerGas),
+		WithdrawalsRoot:       block.WithdrawalsRoot,
+		Withdrawals:           block.Withdrawals,
+		BlobGasUsed:           (*HexUint64)(block.BlobGasUsed),
+		ExcessBlobGas:         (*HexUint64)(block.ExcessBlobGas),
+		ParentBeaconBlockRoot: block.ParentBeaconBlockRoot,
+		RequestsHash:          block.RequestsHash,
 	}
+	return json.Marshal(&enc)
+}
+
+// UnmarshalJSON decodes a block in the JSON-RPC form
+func (block *Block) UnmarshalJSON(b []byte) error {
+
+	var dec blockJSON
+	err := json.Unmarshal(b, &dec)
+	if err != nil {
+		return err
+	}
+
+	var nonce *big.Int
+	if dec.Nonce != nil {
+		nonce = new(big.Int).SetBytes(dec.Nonce)
+	}
+
+	*block = Block{
+		Author:            dec.Author,
+		Difficulty:        dec.Difficulty.ToInt(),
+		ExtraData:         dec.ExtraData,
+		GasLimit:          uint64(dec.GasLimit),
+		GasUsed:           uint64(dec.GasUsed),
+		Hash:              dec.Hash,
+		LogsBloom:         dec.LogsBloom,
+		Miner:             dec.Miner,
+		MixHash:           dec.MixHash,
+		Nonce:             nonce,
+		Number:            uint64(dec.Number),
+		ParentHash:        dec.ParentHash,
+		ReceiptsRoot:      dec.ReceiptsRoot,
+		SealFields:        dec.SealFields,
+		SHA3Uncles:        dec.SHA3Uncles,
+		Size:              uint64(dec.Size),
+		StateRoot:         dec.StateRoot,
+		Timestamp:         uint64(dec.Timestamp),
+		TotalDifficulty:   dec.TotalDifficulty.ToInt(),
+		Transactions:      dec.Transactions.full,
+		TransactionsRoot:  dec.TransactionsRoot,
+		Uncles:            dec.Uncles,
+		TransactionHashes: dec.Transactions.hashes,
+
+		BaseFeePerGas:         dec.BaseFeePerGas.ToInt(),
+		WithdrawalsRoot:       dec.WithdrawalsRoot,
+		Withdrawals:           dec.Withdrawals,
+		BlobGasUsed:           (*uint64)(dec.BlobGasUsed),
+		ExcessBlobGas:         (*uint64)(dec.ExcessBlobGas),
+		ParentBeaconBlockRoot: dec.ParentBeaconBlockRoot,
+		RequestsHash:          dec.RequestsHash,
+	}
+	return nil
+}
+
+// storedBlock is the snake_case form of a Block. The embedded fields encode
+// by their struct tags, and the nested values are replaced by their stored
+// forms. The author is a string as blocks stored before it was typed have
+// "" for none.
+type storedBlock struct {
+	blockFields
+	Author       *string             `json:"author"`
+	Transactions []storedTransaction `json:"transactions"`
+	Withdrawals  []storedWithdrawal  `json:"withdrawals"`
+}
 
-	// populate the transactions in the block
-	numTxs := len(block.Transactions)
-	blockResult.Transactions = make([]TransactionResult, numTxs, numTxs)
-	for i, tx := range block.Transactions {
-		txResult, err := tx.ToTransactionResult()
+// blockFields has the fields of a Block without its JSON methods
+type blockFields Block
+
+// NewBlockFromJSON creates a new Block from its snake_case stored form
+func NewBlockFromJSON(b []byte) (*Block, error) {
+	var stored storedBlock
+	err := json.Unmarshal(b, &stored)
+	if err != nil {
+		return nil, err
+	}
+
+	block := Block(stored.blockFields)
+	block.Author = nil
+	if stored.Author != nil && *stored.Author != "" {
+		author, err := ParseAddress(*stored.Author)
 		if err != nil {
 			return nil, err
 		}
-		blockResult.Transactions[i] = *txResult
+		block.Author = &author
 	}
-
-	return &blockResult, nil
+	block.Transactions = nil
+	if stored.Transactions != nil {
+		block.Transactions = make([]Transaction, len(stored.Transactions))
+		for i := range stored.Transactions {
+			block.Transactions[i] = stored.Transactions[i].transaction()
+		}
+	}
+	block.Withdrawals = nil
+	if stored.Withdrawals != nil {
+		block.Withdrawals = make([]Withdrawal, len(stored.Withdrawals))
+		for i := range stored.Withdrawals {
+			block.Withdrawals[i] = Withdrawal(stored.Withdrawals[i])
+		}
+	}
+	return &block, nil
 }
 
-// ToJSON marshals a Block into JSON
+// ToJSON marshals a Block into its snake_case stored form
 func (block *Block) ToJSON() ([]byte, error) {
-	s, err := json.Marshal(block)
+
+	stored := storedBlock{blockFields: blockFields(*block)}
+	if block.Author != nil {
+		author := block.Author.Hex()
+		stored.Author = &author
+	}
+	if block.Transactions != nil {
+		stored.Transactions = make([]storedTransaction, len(block.Transactions))
+		for i := range block.Transactions {
+			stored.Transactions[i] = newStoredTransaction(&block.Transactions[i])
+		}
+	}
+	if block.Withdrawals != nil {
+		stored.Withdrawals = make([]storedWithdrawal, len(block.Withdrawals))
+		for i := range block.Withdrawals {
+			stored.Withdrawals[i] = storedWithdrawal(block.Withdrawals[i])
+		}
+	}
+
+	s, err := json.Marshal(&stored)
 	if err != nil {
 		return nil, err
 	}
 	return s, nil
 }
+
+// Equals determines whether two Blocks are equal, including their
+// transactions, uncles and withdrawals
+func (block *Block) Equals(block2 *Block) bool {
+
+	if block.GasLimit != block2.GasLimit ||
+		block.GasUsed != block2.GasUsed ||
+		block.Hash != block2.Hash ||
+		block.LogsBloom != block2.LogsBloom ||
+		block.Miner != block2.Miner ||
+		block.MixHash != block2.MixHash ||
+		block.Number != block2.Number ||
+		block.ParentHash != block2.ParentHash ||
+		block.ReceiptsRoot != block2.ReceiptsRoot ||
+		block.SHA3Uncles != block2.SHA3Uncles ||
+		block.Size != block2.Size ||
+		block.StateRoot != block2.StateRoot ||
+		block.Timestamp != block2.Timestamp ||
+		block.TransactionsRoot != block2.TransactionsRoot ||
+		!block.ExtraData.Equal(block2.ExtraData) {
+		return false
+	}
+
+	// big integers
+	if !AreEqualBigInt(block.Difficulty, block2.Difficulty) ||
+		!AreEqualBigInt(block.Nonce, block2.Nonce) ||
+		!AreEqualBigInt(block.TotalDifficulty, block2.TotalDifficulty) {
+		return false
+	}
+
+	// Parity only
+	if !AreEqualAddress(block.Author, block2.Author) ||
+		(block.SealFields == nil) != (block2.SealFields == nil) ||
+		len(block.SealFields) != len(block2.SealFields) {
+		return false
+	}
+	for i := range block.SealFields {
+		if !block.SealFields[i].Equal(block2.SealFields[i]) {
+			return false
+		}
+	}
+
+	// forks
+	if !AreEqualBigInt(block.BaseFeePerGas, block2.BaseFeePerGas) ||
+		!AreEqualHash(block.WithdrawalsRoot, block2.WithdrawalsRoot) ||
+		!AreEqualUint64(block.BlobGasUsed, block2.BlobGasUsed) ||
+		!AreEqualUint64(block.ExcessBlobGas, block2.ExcessBlobGas) ||
+		!AreEqualHash(block.ParentBeaconBlockRoot, block2.ParentBeaconBlockRoot) ||
+		!AreEqualHash(block.RequestsHash, block2.RequestsHash) {
+		return false
+	}
+
+	// uncles are null in some responses and empty in others, which is the
+	// same
+	if !AreEqualHashSlice(block.Uncles, block2.Uncles) {
+		return false
+	}
+
+	// withdrawals are null before Shanghai and may be empty after
+	if (block.Withdrawals == nil) != (block2.Withdrawals == nil) ||
+		len(block.Withdrawals) != len(block2.Withdrawals) {
+		return false
+	}
+	for i := range block.Withdrawals {
+		if !block.Withdrawals[i].Equals(&block2.Withdrawals[i]) {
+			return false
+		}
+	}
+
+	if !AreEqualHashSlice(block.TransactionHashes, block2.TransactionHashes) ||
+		len(block.Transactions) != len(block2.Transactions) {
+		return false
+	}
+	for i := range block.Transactions {
+		if !block.Transactions[i].Equals(&block2.Transactions[i]) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,139 @@
+package jsonrpc_client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// BlockNumberOrTag identifies a block in JSON-RPC parameters: by number, by a
+// named tag such as "finalized", or by hash as an EIP-1898 block object. The
+// zero value is the latest block.
+type BlockNumberOrTag struct {
+	number           *uint64
+	tag              string
+	hash             *Hash
+	requireCanonical bool
+}
+
+// Named block tags
+var (
+	LatestBlock    = BlockNumberOrTag{tag: "latest"}
+	PendingBlock   = BlockNumberOrTag{tag: "pending"}
+	SafeBlock      = BlockNumberOrTag{tag: "safe"}
+	FinalizedBlock = BlockNumberOrTag{tag: "finalized"}
+	EarliestBlock  = BlockNumberOrTag{tag: "earliest"}
+)
+
+// BlockAtNumber identifies the block with the given number
+func BlockAtNumber(number uint64) BlockNumberOrTag {
+	return BlockNumberOrTag{number: &number}
+}
+
+// BlockAtHash identifies the block with the given hash. With requireCanonical
+// the node fails the call if the block is not on the canonical chain.
+func BlockAtHash(hash Hash, requireCanonical bool) BlockNumberOrTag {
+	return BlockNumberOrTag{hash: &hash, requireCanonical: requireCanonical}
+}
+
+// Number returns the block number, if the block is identified by number
+func (block BlockNumberOrTag) Number() (uint64, bool) {
+	if block.number == nil {
+		return 0, false
+	}
+	return *block.number, true
+}
+
+// Tag returns the named tag, if the block is identified by one
+func (block BlockNumberOrTag) Tag() (string, bool) {
+	if block.number != nil || block.hash != nil {
+		return "", false
+	}
+	if block.tag == "" {
+		return "latest", true
+	}
+	return block.tag, true
+}
+
+// Hash returns the block hash and whether it must be canonical, if the block
+// is identified by hash
+func (block BlockNumberOrTag) Hash() (Hash, bool, bool) {
+	if block.hash == nil {
+		return Hash{}, false, false
+	}
+	return *block.hash, block.requireCanonical, true
+}
+
+// String returns the hex number, tag or hash identifying the block
+func (block BlockNumberOrTag) String() string {
+	if block.number != nil {
+		return "0x" + strconv.FormatUint(*block.number, 16)
+	}
+	if block.hash != nil {
+		return block.hash.String()
+	}
+	tag, _ := block.Tag()
+	return tag
+}
+
+// MarshalJSON encodes numbers as hex quantities, tags as strings and hashes
+// as EIP-1898 block objects
+func (block BlockNumberOrTag) MarshalJSON() ([]byte, error) {
+	if block.hash != nil {
+		return json.Marshal(struct {
+			BlockHash        Hash `json:"blockHash"`
+			RequireCanonical bool `json:"requireCanonical,omitempty"`
+		}{*block.hash, block.requireCanonical})
+	}
+	return json.Marshal(block.String())
+}
+
+// UnmarshalJSON decodes a hex quantity, a tag or an EIP-1898 block object
+func (block *BlockNumberOrTag) UnmarshalJSON(b []byte) error {
+
+	b = bytes.TrimSpace(b)
+	if len(b) > 0 && b[0] == '{' {
+		var obj struct {
+			BlockHash        *Hash   `json:"blockHash"`
+			BlockNumber      *string `json:"blockNumber"`
+			RequireCanonical bool    `json:"requireCanonical"`
+		}
+		err := json.Unmarshal(b, &obj)
+		if err != nil {
+			return err
+		}
+		switch {
+		case obj.BlockHash != nil && obj.BlockNumber == nil:
+			*block = BlockAtHash(*obj.BlockHash, obj.RequireCanonical)
+			return nil
+		case obj.BlockNumber != nil && obj.BlockHash == nil:
+			return block.parse(*obj.BlockNumber)
+		default:
+			return fmt.Errorf("BlockNumberOrTag: need exactly one of blockHash and blockNumber")
+		}
+	}
+
+	var s string
+	err := json.Unmarshal(b, &s)
+	if err != nil {
+		return err
+	}
+	return block.parse(s)
+}
+
+// parse sets block from a hex quantity or a tag
+func (block *BlockNumberOrTag) parse(s string) error {
+	switch s {
+	case "latest", "pending", "safe", "finalized", "earliest":
+		*block = BlockNumberOrTag{tag: s}
+		return nil
+	}
+
+	number, err := ParseHexUint64(s)
+	if err != nil {
+		return fmt.Errorf("BlockNumberOrTag: invalid block: %w", err)
+	}
+	*block = BlockAtNumber(number)
+	return nil
+}
@@ -0,0 +1,127 @@
+package jsonrpc_client
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestBlockNumberOrTagJSON(t *testing.T) {
+
+	hash := Hash{31: 0xab}
+	tests := []struct {
+		block BlockNumberOrTag
+		want  string
+	}{
+		{BlockNumberOrTag{}, `"latest"`},
+		{LatestBlock, `"latest"`},
+		{PendingBlock, `"pending"`},
+		{SafeBlock, `"safe"`},
+		{FinalizedBlock, `"finalized"`},
+		{EarliestBlock, `"earliest"`},
+		{BlockAtNumber(0), `"0x0"`},
+		{BlockAtNumber(0x12ab), `"0x12ab"`},
+		{BlockAtHash(hash, false), `{"blockHash":"` + hash.String() + `"}`},
+		{BlockAtHash(hash, true), `{"blockHash":"` + hash.String() + `","requireCanonical":true}`},
+	}
+	for _, test := range tests {
+		b, err := json.Marshal(test.block)
+		if err != nil || string(b) != test.want {
+			t.Errorf("Marshal(%v) = %s, %v, want %s", test.block, b, err, test.want)
+			continue
+		}
+		var decoded BlockNumberOrTag
+		err = json.Unmarshal(b, &decoded)
+		if err != nil || decoded.String() != test.block.String() {
+			t.Errorf("Unmarshal(%s) = %v, %v", b, decoded, err)
+		}
+	}
+
+	var block BlockNumberOrTag
+	err := json.Unmarshal([]byte(`{"blockNumber":"0x10"}`), &block)
+	if number, ok := block.Number(); err != nil || !ok || number != 0x10 {
+		t.Errorf("Unmarshal(EIP-1898 number) = %v, %v", block, err)
+	}
+	err = json.Unmarshal([]byte(`{"blockHash":"`+hash.String()+`","requireCanonical":true}`), &block)
+	if got, canonical, ok := block.Hash(); err != nil || !ok || !canonical || got != hash {
+		t.Errorf("Unmarshal(EIP-1898 hash) = %v, %v", block, err)
+	}
+
+	invalid := []string{
+		`"newest"`,
+		`"0xzz"`,
+		`""`,
+		`{}`,
+		`{"blockNumber":"0x1","blockHash":"` + hash.String() + `"}`,
+		// only hex quantities are block numbers
+		`"16"`,
+		`"0o20"`,
+		`"0b10000"`,
+		`"0x1_0"`,
+		`"0x010"`,
+		`{"blockNumber":"16"}`,
+	}
+	for _, s := range invalid {
+		err := json.Unmarshal([]byte(s), &block)
+		if err == nil {
+			t.Errorf("Unmarshal(%s) accepted an invalid block", s)
+		}
+	}
+}
+
+func TestBlockNumberOrTagAccessors(t *testing.T) {
+
+	if tag, ok := (BlockNumberOrTag{}).Tag(); !ok || tag != "latest" {
+		t.Errorf("zero value Tag() = %q, %t, want latest", tag, ok)
+	}
+	if _, ok := BlockAtNumber(1).Tag(); ok {
+		t.Errorf("BlockAtNumber(1).Tag() reported a tag")
+	}
+	if _, ok := FinalizedBlock.Number(); ok {
+		t.Errorf("FinalizedBlock.Number() reported a number")
+	}
+	if _, _, ok := BlockAtNumber(1).Hash(); ok {
+		t.Errorf("BlockAtNumber(1).Hash() reported a hash")
+	}
+}
+
+func TestClientBlockParameters(t *testing.T) {
+
+	var sent []string
+	server := newRPCServer(t, func(method string, params []json.RawMessage) (interface{}, error) {
+		// the block comes last, except for the full flag of eth_getBlockByNumber
+		if method == "eth_getBlockByNumber" {
+			sent = append(sent, method+" "+string(params[0]))
+			return json.RawMessage(mainnetBlock1), nil
+		}
+		sent = append(sent, method+" "+string(params[len(params)-1]))
+		if method == "eth_getCode" {
+			return "0x", nil
+		}
+		return "0x1", nil
+	})
+	client := NewEthereumClient(server.URL)
+
+	hash := Hash{31: 0xab}
+	block, err := client.Eth_getBlockByNumber(FinalizedBlock, false)
+	if err != nil || block.Number != 1 {
+		t.Fatalf("Eth_getBlockByNumber() = %v, %v", block, err)
+	}
+	client.Eth_getBalance(Address{}, SafeBlock)
+	client.Eth_getTransactionCount(Address{}, BlockAtNumber(0x10))
+	client.Eth_getCode(Address{}, BlockAtHash(hash, true))
+
+	want := []string{
+		`eth_getBlockByNumber "finalized"`,
+		`eth_getBalance "safe"`,
+		`eth_getTransactionCount "0x10"`,
+		`eth_getCode {"blockHash":"` + hash.String() + `","requireCanonical":true}`,
+	}
+	if len(sent) != len(want) {
+		t.Fatalf("sent %q, want %q", sent, want)
+	}
+	for i := range want {
+		if sent[i] != want[i] {
+			t.Errorf("sent %s, want %s", sent[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package jsonrpc_client
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+// baselineStoredBlock is a block in the stored form written before the
+// fields were typed: strings for the author, hashes and signature values,
+// and JSON numbers for the quantities
+const baselineStoredBlock = `{
+	"author": "",
+	"difficulty": 1,
+	"extra_data": "0x",
+	"gas_limit": 30000000,
+	"gas_used": 21000,
+	"hash": "0x88e96d4537bea4d9c05d12549907b32561d3bf31f45aae734cdc119f13406cb6",
+	"logs_bloom": "0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+	"miner": "0x05a56e2d52c817161883f50c441c3228cfe54d9f",
+	"mix_hash": "0x969b900de27b6ac6a67742365dd65f55a0526c41fd18e1b16f1a1215c2e66f59",
+	"nonce": 6024642674226568900,
+	"number": 1,
+	"parent_hash": "0xd4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3",
+	"receipts_root": "0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421",
+	"seal_fields": null,
+	"sha3_uncles": "0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347",
+	"size": 537,
+	"state_root": "0xd67e4d450343046425ae4271474353857ab860dbc0a1dde64b41b5cd3a532bf3",
+	"timestamp": 1438269988,
+	"total_difficulty": 34351349760,
+	"transactions": [{
+		"block_hash": "0x88e96d4537bea4d9c05d12549907b32561d3bf31f45aae734cdc119f13406cb6",
+		"block_number": 1,
+		"from": "0xa1e4380a3b1f749673e270229993ee55f35663b4",
+		"gas": 21000,
+		"gas_price": 50000000000000,
+		"hash": "0x5c504ed432cb51138bcf09aa5e8a410dd4a1e204ef84bfed1be16dfba1b22060",
+		"input": "0x",
+		"nonce": 0,
+		"r": "0x88ff6cf0fefd94db46111149ae4bfc179e9b94721fffd821d38d16464b3f71d0",
+		"s": "0x45e0aff800961cfce805daef7016b9b675c137a6a41a548f7b60a3484c06a33a",
+		"to": "0x5df9b87991262f6ba471f09758cde1c0fc1de734",
+		"transaction_index": 0,
+		"v": 28,
+		"value": 31337,
+		"condition": null,
+		"chain_id": null,
+		"creates": null,
+		"public_key": null,
+		"raw": null,
+		"standard_v": 1
+	}],
+	"transactions_root": "0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421",
+	"uncles": []
+}`
+
+func TestNewBlockFromJSONBaseline(t *testing.T) {
+
+	block, err := NewBlockFromJSON([]byte(baselineStoredBlock))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if block.Author != nil {
+		t.Errorf("Author = %v, want nil", block.Author)
+	}
+	if block.Number != 1 || block.TotalDifficulty.Cmp(big.NewInt(34351349760)) != 0 {
+		t.Errorf("Number = %d, TotalDifficulty = %v", block.Number, block.TotalDifficulty)
+	}
+	if len(block.Transactions) != 1 {
+		t.Fatalf("%d transactions, want 1", len(block.Transactions))
+	}
+	tx := block.Transactions[0]
+	if tx.V.Cmp(big.NewInt(28)) != 0 || tx.Value.Cmp(big.NewInt(31337)) != 0 {
+		t.Errorf("V = %v, Value = %v", tx.V, tx.Value)
+	}
+
+	// the block survives a round trip through the current stored form
+	b, err := block.ToJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	block2, err := NewBlockFromJSON(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !block.Equals(block2) {
+		t.Errorf("block changed in a stored form round trip: %s", b)
+	}
+}
+
+func TestNewBlockFromJSONAuthor(t *testing.T) {
+
+	tests := []struct {
+		json   string
+		author *Address
+	}{
+		{`{"author":"","number":1}`, nil},
+		{`{"author":null}`, nil},
+		{`{}`, nil},
+		{`{"author":"0x05a56e2d52c817161883f50c441c3228cfe54d9f"}`, addressPtr("0x05a56e2d52c817161883f50c441c3228cfe54d9f")},
+	}
+	for _, test := range tests {
+		block, err := NewBlockFromJSON([]byte(test.json))
+		if err != nil {
+			t.Errorf("NewBlockFromJSON(%s): %v", test.json, err)
+			continue
+		}
+		if !AreEqualAddress(block.Author, test.author) {
+			t.Errorf("NewBlockFromJSON(%s).Author = %v, want %v", test.json, block.Author, test.author)
+		}
+	}
+
+	_, err := NewBlockFromJSON([]byte(`{"author":"0x1234"}`))
+	if err == nil {
+		t.Errorf("NewBlockFromJSON accepted a short author")
+	}
+}
+
+// addressPtr returns a pointer to the parsed address, panicking if invalid
+func addressPtr(s string) *Address {
+	address, err := ParseAddress(s)
+	if err != nil {
+		panic(err)
+	}
+	return &address
+}
+
+func TestBlockJSON(t *testing.T) {
+
+	// a Cancun block with a withdrawal and full transactions
+	full := strings.Replace(hoodiGenesis, `"transactions": []`, `"transactions": [`+blobTransaction+`,`+setCodeTransaction+`]`, 1)
+	full = strings.Replace(full, `"withdrawals": []`, `"withdrawals": [{"index":"0x1","validatorIndex":"0x2","address":"0x0000000000000000000000000000000000000035","amount":"0x3"}]`, 1)
+	block := mustDecodeBlock(t, full)
+	if len(block.Transactions) != 2 || block.TransactionHashes != nil ||
+		*block.Transactions[0].Type != BLOB_TX_TYPE || *block.Transactions[1].Type != SET_CODE_TX_TYPE {
+		t.Fatalf("decoded the wrong transactions: %+v", block.Transactions)
+	}
+	if len(block.Withdrawals) != 1 || block.Withdrawals[0].ValidatorIndex != 2 || block.Withdrawals[0].Amount != 3 {
+		t.Errorf("decoded the wrong withdrawals: %+v", block.Withdrawals)
+	}
+	if block.Nonce.Uint64() != 0x1234 || block.GasLimit != 0x2255100 || block.BaseFeePerGas.Cmp(big.NewInt(1000000000)) != 0 {
+		t.Errorf("decoded the wrong quantities")
+	}
+
+	// a block fetched without full transactions has their hashes
+	hashes := strings.Replace(hoodiGenesis, `"transactions": []`,
+		`"transactions": ["0x00000000000000000000000000000000000000000000000000000000000000ce"]`, 1)
+	hashBlock := mustDecodeBlock(t, hashes)
+	if hashBlock.Transactions != nil || len(hashBlock.TransactionHashes) != 1 || hashBlock.TransactionHashes[0] != (Hash{31: 0xce}) {
+		t.Errorf("decoded the wrong transaction hashes: %+v, %+v", hashBlock.Transactions, hashBlock.TransactionHashes)
+	}
+
+	for _, block := range []*Block{block, hashBlock, mustDecodeBlock(t, mainnetBlock1)} {
+		b, err := json.Marshal(block)
+		if err != nil {
+			t.Fatalf("Marshal(): %v", err)
+		}
+		decoded := mustDecodeBlock(t, string(b))
+		if !block.Equals(decoded) || len(decoded.TransactionHashes) != len(block.TransactionHashes) {
+			t.Errorf("JSON-RPC round trip changed the block: %s", b)
+		}
+		// the nonce keeps its 8 bytes
+		var fields struct{ Nonce string }
+		if json.Unmarshal(b, &fields) != nil || len(fields.Nonce) != 18 {
+			t.Errorf("nonce %q not encoded as 8 bytes", fields.Nonce)
+		}
+
+		b, err = block.ToJSON()
+		if err != nil {
+			t.Fatalf("ToJSON(): %v", err)
+		}
+		stored, err := NewBlockFromJSON(b)
+		if err != nil || !block.Equals(stored) {
+			t.Errorf("stored round trip changed the block: %s, %v", b, err)
+		}
+	}
+}
+
+func TestBlockStoredForm(t *testing.T) {
+
+	// the stored form keeps snake_case keys and JSON number quantities
+	block := mustDecodeBlock(t, mainnetBlock1)
+	b, err := block.ToJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stored map[string]json.RawMessage
+	err = json.Unmarshal(b, &stored)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for key, want := range map[string]string{
+		"number":           `1`,
+		"gas_limit":        `5000`,
+		"total_difficulty": `34351349760`,
+		"parent_hash":      `"` + block.ParentHash.String() + `"`,
+	} {
+		if string(stored[key]) != want {
+			t.Errorf("stored %s = %s, want %s", key, stored[key], want)
+		}
+	}
+	if _, ok := stored["parentHash"]; ok {
+		t.Errorf("stored form has JSON-RPC keys: %s", b)
+	}
+}
+
+func TestBlockJSONInvalidQuantities(t *testing.T) {
+
+	// quantities must be spec-compliant hex
+	for _, replacement := range [][2]string{
+		{`"number": "0x1"`, `"number": "0x01"`},
+		{`"number": "0x1"`, `"number": "0x"`},
+		{`"number": "0x1"`, `"number": 1`},
+		{`"gasLimit": "0x1388"`, `"gasLimit": "1388"`},
+		{`"difficulty": "0x3ff800000"`, `"difficulty": "0x03ff800000"`},
+	} {
+		s := strings.Replace(mainnetBlock1, replacement[0], replacement[1], 1)
+		if s == mainnetBlock1 {
+			t.Fatalf("%s not found in the block", replacement[0])
+		}
+		var block Block
+		if err := json.Unmarshal([]byte(s), &block); err == nil {
+			t.Errorf("Unmarshal() accepted %s", replacement[1])
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package jsonrpc_client
+
+import (
+	"bytes"
+	"encoding/hex"
+)
+
+// BLOOM_LENGTH is the length of a logs bloom filter in bytes
+const BLOOM_LENGTH = 256
+
+// Bloom is the 2048 bit bloom filter of the addresses and topics of the logs
+// of a receipt or block
+type Bloom [BLOOM_LENGTH]byte
+
+// ParseBloom parses a 0x-prefixed hex string of exactly 256 bytes
+func ParseBloom(s string) (Bloom, error) {
+	var bloom Bloom
+	err := decodeFixedHex(bloom[:], s, "bloom")
+	return bloom, err
+}
+
+// Bytes returns the bytes of the bloom filter
+func (bloom Bloom) Bytes() []byte {
+	return bloom[:]
+}
+
+// String returns the lowercase 0x-prefixed hex encoding
+func (bloom Bloom) String() string {
+	return "0x" + hex.EncodeToString(bloom[:])
+}
+
+// Cmp compares two bloom filters as big-endian numbers, returning -1, 0 or +1
+func (bloom Bloom) Cmp(bloom2 Bloom) int {
+	return bytes.Compare(bloom[:], bloom2[:])
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (bloom Bloom) MarshalText() ([]byte, error) {
+	return []byte(bloom.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (bloom *Bloom) UnmarshalText(text []byte) error {
+	parsed, err := ParseBloom(string(text))
+	if err != nil {
+		return err
+	}
+	*bloom = parsed
+	return nil
+}
@@ -0,0 +1,61 @@
+package jsonrpc_client
+
+import "math/big"
+
+// ChainConfig holds the chain ID and the activation of the forks that change
+// the block header or transaction formats or validity. Homestead and London
+// activate at a block number, later forks at a block timestamp. A nil
+// activation means the fork is not active.
+type ChainConfig struct {
+	ChainId        *big.Int
+	HomesteadBlock *uint64
+	LondonBlock    *uint64
+	ShanghaiTime   *uint64
+	CancunTime     *uint64
+	PragueTime     *uint64
+}
+
+// MainnetChainConfig is the ChainConfig of Ethereum mainnet
+var MainnetChainConfig = &ChainConfig{
+	ChainId:        big.NewInt(1),
+	HomesteadBlock: newUint64(1150000),
+	LondonBlock:    newUint64(12965000),
+	ShanghaiTime:   newUint64(1681338455),
+	CancunTime:     newUint64(1710338135),
+	PragueTime:     newUint64(1746612311),
+}
+
+// SepoliaChainConfig is the ChainConfig of the Sepolia testnet
+var SepoliaChainConfig = &ChainConfig{
+	ChainId:        big.NewInt(11155111),
+	HomesteadBlock: newUint64(0),
+	LondonBlock:    newUint64(0),
+	ShanghaiTime:   newUint64(1677557088),
+	CancunTime:     newUint64(1706655072),
+	PragueTime:     newUint64(1741159776),
+}
+
+// IsHomestead reports whether Homestead is active at the given block number
+func (config *ChainConfig) IsHomestead(number uint64) bool {
+	return config.HomesteadBlock != nil && number >= *config.HomesteadBlock
+}
+
+// IsLondon reports whether London is active at the given block number
+func (config *ChainConfig) IsLondon(number uint64) bool {
+	return config.LondonBlock != nil && number >= *config.LondonBlock
+}
+
+// IsShanghai reports whether Shanghai is active at the given block timestamp
+func (config *ChainConfig) IsShanghai(timestamp uint64) bool {
+	return config.ShanghaiTime != nil && timestamp >= *config.ShanghaiTime
+}
+
+// IsCancun reports whether Cancun is active at the given block timestamp
+func (config *ChainConfig) IsCancun(timestamp uint64) bool {
+	return config.CancunTime != nil && timestamp >= *config.CancunTime
+}
+
+// IsPrague reports whether Prague is active at the given block timestamp
+func (config *ChainConfig) IsPrague(timestamp uint64) bool {
+	return config.PragueTime != nil && timestamp >= *config.PragueTime
+}
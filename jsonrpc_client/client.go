@@ -1,11 +1,16 @@
 package jsonrpc_client
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
+	"io"
 	"io/ioutil"
+	"math/big"
 	"net/http"
-	"strconv"
-	"strings"
+	"sync/atomic"
+	"time"
 )
 
 type JSONRPCRequest struct {
@@ -25,42 +30,227 @@ func (req *JSONRPCRequest) ToJSON() ([]byte, error) {
 }
 
 type EthereumClient struct {
+	// lastID is accessed atomically and kept first for 64-bit alignment
+	lastID int64
+
 	URL string
+
+	// transport replaces the HTTP transport when set, e.g. by a WebSocketClient
+	transport       transport
+	httpClient      *http.Client
+	header          http.Header
+	timeout         time.Duration
+	maxResponseSize int64
+	decodeMode      DecodeMode
+	retry           RetryPolicy
+	limiter         *RateLimiter
+}
+
+// NewEthereumClient creates a new EthereumClient for the given URL
+func NewEthereumClient(url string, opts ...ClientOption) *EthereumClient {
+	client := &EthereumClient{
+		URL:             url,
+		header:          make(http.Header),
+		maxResponseSize: MAX_RESPONSE_SIZE,
+	}
+	for _, opt := range opts {
+		opt(client)
+	}
+	return client
+}
+
+// transport carries JSON-RPC payloads for an EthereumClient
+type transport interface {
+	roundTrip(ctx context.Context, payload []byte, ids []int64) ([]byte, error)
+}
+
+// getHTTPClient returns the configured http.Client, or http.DefaultClient
+func (client *EthereumClient) getHTTPClient() *http.Client {
+	if client.httpClient != nil {
+		return client.httpClient
+	}
+	return http.DefaultClient
+}
+
+// nextID allocates a request ID unique to this client
+func (client *EthereumClient) nextID() int64 {
+	return atomic.AddInt64(&client.lastID, 1)
 }
 
 // issueRequest issues the JSON-RPC request
-func (client *EthereumClient) issueRequest(reqBody *JSONRPCRequest) ([]byte, error) {
+func (client *EthereumClient) issueRequest(ctx context.Context, reqBody *JSONRPCRequest) ([]byte, error) {
 
+	reqBody.ID = client.nextID()
 	payload, err := reqBody.ToJSON()
 	if err != nil {
 		return nil, err
 	}
 
-	reader := strings.NewReader(string(payload))
-	resp, err := http.Post(client.URL, JSON_MEDIA_TYPE, reader)
+	body, err := client.roundTrip(ctx, payload, []int64{reqBody.ID}, []string{reqBody.Method})
+	if err != nil {
+		return nil, err
+	}
+
+	var respBase ResponseBase
+	err = json.Unmarshal(body, &respBase)
+	if err != nil {
+		return nil, err
+	}
+	err = respBase.validate(reqBody.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return body, nil
+}
+
+// roundTrip sends a JSON-RPC payload carrying the requests with the given IDs
+// and methods, within the budget of the rate limiter and retrying transient
+// failures as allowed by the retry policy, and returns the raw response
+func (client *EthereumClient) roundTrip(ctx context.Context, payload []byte, ids []int64, methods []string) ([]byte, error) {
+
+	idempotent := client.retry.isIdempotent(methods)
+	for retry := 0; ; retry++ {
+
+		if client.limiter != nil {
+			err := client.limiter.wait(ctx, methods)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		body, err := client.attempt(ctx, payload, ids)
+		retryErr := err
+		if err == nil {
+			retryErr = rateLimitError(body)
+			if retryErr == nil {
+				return body, nil
+			}
+		}
+
+		delay, ok := client.retry.retryDelay(retryErr, retry, idempotent)
+		if !ok {
+			return body, err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// attempt sends a JSON-RPC payload once and returns the raw response
+func (client *EthereumClient) attempt(ctx context.Context, payload []byte, ids []int64) ([]byte, error) {
+
+	if client.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, client.timeout)
+		defer cancel()
+	}
+
+	if client.transport != nil {
+		return client.transport.roundTrip(ctx, payload, ids)
+	}
+	return client.post(ctx, payload)
+}
+
+// post sends a JSON payload to the client's URL and returns the response
+// body. Responses that aren't JSON or come with a status other than 2xx fail
+// with a *TransportError.
+func (client *EthereumClient) post(ctx context.Context, payload []byte) ([]byte, error) {
+
+	req, err := http.NewRequest(http.MethodPost, client.URL, bytes.NewReader(payload))
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", JSON_MEDIA_TYPE)
+	for key, values := range client.header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
 
-	body, err := ioutil.ReadAll(resp.Body)
+	resp, err := client.getHTTPClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
 	defer resp.Body.Close()
+
+	// read one byte past the limit to detect oversized responses
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, client.maxResponseSize+1))
 	if err != nil {
 		return nil, err
 	}
+	if int64(len(body)) > client.maxResponseSize {
+		return nil, fmt.Errorf("%w: more than %d bytes", ErrResponseTooLarge, client.maxResponseSize)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 || !isJSONResponse(body) {
+		return nil, newTransportError(resp, body)
+	}
 	return body, nil
 }
 
+// isJSONResponse reports whether body may be a JSON-RPC response or batch
+func isJSONResponse(body []byte) bool {
+	body = bytes.TrimSpace(body)
+	return len(body) > 0 && (body[0] == '{' || body[0] == '[')
+}
+
+// Call calls any JSON-RPC method with the given context, e.g. one without a
+// dedicated method such as debug_traceTransaction, and unmarshals its result
+// into result, which may be any type json.Unmarshal decodes into. The result
+// is discarded if result is nil.
+func (client *EthereumClient) Call(ctx context.Context, method string, result interface{}, params ...interface{}) error {
+
+	// nodes reject null params for methods without any
+	if params == nil {
+		params = []interface{}{}
+	}
+
+	reqBody := JSONRPCRequest{
+		JSONRPC: "2.0",
+		Method:  method,
+		Params:  params,
+	}
+
+	body, err := client.issueRequest(ctx, &reqBody)
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		return nil
+	}
+
+	var clientResp RawResponse
+	err = json.Unmarshal(body, &clientResp)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(clientResp.Result, result)
+}
+
 // Eth_newBlockFilter calls the eth_newBlockFilter JSON-RPC method
 func (client *EthereumClient) Eth_newBlockFilter() (string, error) {
+	return client.Eth_newBlockFilterContext(context.Background())
+}
+
+// Eth_newBlockFilterContext calls the eth_newBlockFilter JSON-RPC method with the given context
+func (client *EthereumClient) Eth_newBlockFilterContext(ctx context.Context) (string, error) {
 
 	reqBody := JSONRPCRequest{
 		JSONRPC: "2.0",
-		ID:      1,
 		Method:  "eth_newBlockFilter",
-		Params:  nil,
+		Params:  []interface{}{},
 	}
 
-	body, err := client.issueRequest(&reqBody)
+	body, err := client.issueRequest(ctx, &reqBody)
 	if err != nil {
 		return "", err
 	}
@@ -76,15 +266,19 @@ func (client *EthereumClient) Eth_newBlockFilter() (string, error) {
 
 // Eth_newPendingTransactionFilter calls the eth_newPendingTransactionFilter JSON-RPC method
 func (client *EthereumClient) Eth_newPendingTransactionFilter() (string, error) {
+	return client.Eth_newPendingTransactionFilterContext(context.Background())
+}
+
+// Eth_newPendingTransactionFilterContext calls the eth_newPendingTransactionFilter JSON-RPC method with the given context
+func (client *EthereumClient) Eth_newPendingTransactionFilterContext(ctx context.Context) (string, error) {
 
 	reqBody := JSONRPCRequest{
 		JSONRPC: "2.0",
-		ID:      1,
 		Method:  "eth_newPendingTransactionFilter",
-		Params:  nil,
+		Params:  []interface{}{},
 	}
 
-	body, err := client.issueRequest(&reqBody)
+	body, err := client.issueRequest(ctx, &reqBody)
 	if err != nil {
 		return "", err
 	}
@@ -99,16 +293,20 @@ func (client *EthereumClient) Eth_newPendingTransactionFilter() (string, error)
 }
 
 // Eth_getFilterChanges calls the eth_getFilterChanges JSON-RPC method
-func (client *EthereumClient) Eth_getFilterChanges(filterID string) ([]string, error) {
+func (client *EthereumClient) Eth_getFilterChanges(filterID string) ([]Hash, error) {
+	return client.Eth_getFilterChangesContext(context.Background(), filterID)
+}
+
+// Eth_getFilterChangesContext calls the eth_getFilterChanges JSON-RPC method with the given context
+func (client *EthereumClient) Eth_getFilterChangesContext(ctx context.Context, filterID string) ([]Hash, error) {
 
 	reqBody := JSONRPCRequest{
 		JSONRPC: "2.0",
-		ID:      1,
 		Method:  "eth_getFilterChanges",
 		Params:  []interface{}{filterID},
 	}
 
-	body, err := client.issueRequest(&reqBody)
+	body, err := client.issueRequest(ctx, &reqBody)
 	if err != nil {
 		return nil, err
 	}
@@ -122,106 +320,129 @@ func (client *EthereumClient) Eth_getFilterChanges(filterID string) ([]string, e
 	return clientResp.Result, nil
 }
 
-// Eth_getBlockByHash calls the eth_getBlockByHash JSON-RPC method
-func (client *EthereumClient) Eth_getBlockByHash(blockHash string, full bool) (*Block, error) {
+// Eth_getBlockByHash calls the eth_getBlockByHash JSON-RPC method.
+// It returns a nil Block for unknown blocks, and fills TransactionHashes
+// instead of Transactions unless full is set.
+func (client *EthereumClient) Eth_getBlockByHash(blockHash Hash, full bool) (*Block, error) {
+	return client.Eth_getBlockByHashContext(context.Background(), blockHash, full)
+}
+
+// Eth_getBlockByHashContext calls the eth_getBlockByHash JSON-RPC method with the given context
+func (client *EthereumClient) Eth_getBlockByHashContext(ctx context.Context, blockHash Hash, full bool) (*Block, error) {
 
 	reqBody := JSONRPCRequest{
 		JSONRPC: "2.0",
-		ID:      1,
 		Method:  "eth_getBlockByHash",
 		Params:  []interface{}{blockHash, full},
 	}
 
-	body, err := client.issueRequest(&reqBody)
+	body, err := client.issueRequest(ctx, &reqBody)
 	if err != nil {
 		return nil, err
 	}
 
-	var clientResp BlockResponse
-	err = json.Unmarshal(body, &clientResp)
+	err = client.checkResult(body, checkBlock)
 	if err != nil {
 		return nil, err
 	}
 
-	block, err := clientResp.Result.ToBlock()
+	var clientResp BlockResponse
+	err = json.Unmarshal(body, &clientResp)
 	if err != nil {
 		return nil, err
 	}
 
-	return block, nil
+	return clientResp.Result, nil
+}
+
+// Eth_getTransactionByHash calls the eth_getTransactionByHash JSON-RPC method.
+// It returns a nil Transaction for unknown transactions.
+func (client *EthereumClient) Eth_getTransactionByHash(txHash Hash) (*Transaction, error) {
+	return client.Eth_getTransactionByHashContext(context.Background(), txHash)
 }
 
-// Eth_getTransactionByHash calls the eth_getTransactionByHash JSON-RPC method
-func (client *EthereumClient) Eth_getTransactionByHash(txHash string) (*Transaction, error) {
+// Eth_getTransactionByHashContext calls the eth_getTransactionByHash JSON-RPC method with the given context
+func (client *EthereumClient) Eth_getTransactionByHashContext(ctx context.Context, txHash Hash) (*Transaction, error) {
 
 	reqBody := JSONRPCRequest{
 		JSONRPC: "2.0",
-		ID:      1,
 		Method:  "eth_getTransactionByHash",
 		Params:  []interface{}{txHash},
 	}
 
-	body, err := client.issueRequest(&reqBody)
+	body, err := client.issueRequest(ctx, &reqBody)
 	if err != nil {
 		return nil, err
 	}
 
-	var clientResp TransactionResponse
-	err = json.Unmarshal(body, &clientResp)
+	err = client.checkResult(body, checkTransaction)
 	if err != nil {
 		return nil, err
 	}
 
-	tx, err := clientResp.Result.ToTransaction()
+	var clientResp TransactionResponse
+	err = json.Unmarshal(body, &clientResp)
 	if err != nil {
 		return nil, err
 	}
 
-	return tx, nil
+	return clientResp.Result, nil
 }
 
-// Eth_getBlockByNumber calls the eth_getBlockByNumber JSON-RPC method
-func (client *EthereumClient) Eth_getBlockByNumber(blockNumber int, full bool) (*Block, error) {
+// Eth_getBlockByNumber calls the eth_getBlockByNumber JSON-RPC method.
+// It returns a nil Block for unknown blocks, and fills TransactionHashes
+// instead of Transactions unless full is set.
+func (client *EthereumClient) Eth_getBlockByNumber(blockNumber BlockNumberOrTag, full bool) (*Block, error) {
+	return client.Eth_getBlockByNumberContext(context.Background(), blockNumber, full)
+}
 
-	blockNumberHex := "0x" + strconv.FormatInt(int64(blockNumber), 16)
+// Eth_getBlockByNumberContext calls the eth_getBlockByNumber JSON-RPC method with the given context
+func (client *EthereumClient) Eth_getBlockByNumberContext(ctx context.Context, blockNumber BlockNumberOrTag, full bool) (*Block, error) {
+
+	if _, _, ok := blockNumber.Hash(); ok {
+		return nil, fmt.Errorf("Eth_getBlockByNumber: use Eth_getBlockByHash for block hashes")
+	}
 
 	reqBody := JSONRPCRequest{
 		JSONRPC: "2.0",
-		ID:      1,
 		Method:  "eth_getBlockByNumber",
-		Params:  []interface{}{blockNumberHex, full},
+		Params:  []interface{}{blockNumber, full},
 	}
 
-	body, err := client.issueRequest(&reqBody)
+	body, err := client.issueRequest(ctx, &reqBody)
 	if err != nil {
 		return nil, err
 	}
 
-	var clientResp BlockResponse
-	err = json.Unmarshal(body, &clientResp)
+	err = client.checkResult(body, checkBlock)
 	if err != nil {
 		return nil, err
 	}
 
-	block, err := clientResp.Result.ToBlock()
+	var clientResp BlockResponse
+	err = json.Unmarshal(body, &clientResp)
 	if err != nil {
 		return nil, err
 	}
 
-	return block, nil
+	return clientResp.Result, nil
 }
 
 // Eth_blockNumber calls the eth_blockNumber JSON-RPC method
-func (client *EthereumClient) Eth_blockNumber() (int, error) {
+func (client *EthereumClient) Eth_blockNumber() (uint64, error) {
+	return client.Eth_blockNumberContext(context.Background())
+}
+
+// Eth_blockNumberContext calls the eth_blockNumber JSON-RPC method with the given context
+func (client *EthereumClient) Eth_blockNumberContext(ctx context.Context) (uint64, error) {
 
 	reqBody := JSONRPCRequest{
 		JSONRPC: "2.0",
-		ID:      1,
 		Method:  "eth_blockNumber",
 		Params:  []interface{}{},
 	}
 
-	body, err := client.issueRequest(&reqBody)
+	body, err := client.issueRequest(ctx, &reqBody)
 	if err != nil {
 		return 0, err
 	}
@@ -232,25 +453,24 @@ func (client *EthereumClient) Eth_blockNumber() (int, error) {
 		return 0, err
 	}
 
-	blockNumber, err := strconv.ParseInt(clientResp.Result, 0, 32)
-	if err != nil {
-		return 0, err
-	}
-
-	return int(blockNumber), nil
+	return uint64(clientResp.Result), nil
 }
 
 // Web3_clientVersion calls the web3_clientVersion JSON-RPC method
 func (client *EthereumClient) Web3_clientVersion() (string, error) {
+	return client.Web3_clientVersionContext(context.Background())
+}
+
+// Web3_clientVersionContext calls the web3_clientVersion JSON-RPC method with the given context
+func (client *EthereumClient) Web3_clientVersionContext(ctx context.Context) (string, error) {
 
 	reqBody := JSONRPCRequest{
 		JSONRPC: "2.0",
-		ID:      1,
 		Method:  "web3_clientVersion",
 		Params:  []interface{}{},
 	}
 
-	body, err := client.issueRequest(&reqBody)
+	body, err := client.issueRequest(ctx, &reqBody)
 	if err != nil {
 		return "", err
 	}
@@ -264,17 +484,212 @@ func (client *EthereumClient) Web3_clientVersion() (string, error) {
 	return clientResp.Result, nil
 }
 
-// Eth_syncing calls the eth_syncing JSON-RPC method
+// Eth_syncing calls the eth_syncing JSON-RPC method and reports whether the
+// node is still syncing
 func (client *EthereumClient) Eth_syncing() (bool, error) {
+	return client.Eth_syncingContext(context.Background())
+}
+
+// Eth_syncingContext calls the eth_syncing JSON-RPC method with the given context
+func (client *EthereumClient) Eth_syncingContext(ctx context.Context) (bool, error) {
 
 	reqBody := JSONRPCRequest{
 		JSONRPC: "2.0",
-		ID:      1,
 		Method:  "eth_syncing",
 		Params:  []interface{}{},
 	}
 
-	body, err := client.issueRequest(&reqBody)
+	body, err := client.issueRequest(ctx, &reqBody)
+	if err != nil {
+		return false, err
+	}
+
+	var clientResp SyncStatusResponse
+	err = json.Unmarshal(body, &clientResp)
+	if err != nil {
+		return false, err
+	}
+
+	return clientResp.Result.Syncing, nil
+}
+
+// Eth_getTransactionReceipt calls the eth_getTransactionReceipt JSON-RPC method.
+// It returns a nil Receipt for unknown and pending transactions.
+func (client *EthereumClient) Eth_getTransactionReceipt(txHash Hash) (*Receipt, error) {
+	return client.Eth_getTransactionReceiptContext(context.Background(), txHash)
+}
+
+// Eth_getTransactionReceiptContext calls the eth_getTransactionReceipt JSON-RPC method with the given context
+func (client *EthereumClient) Eth_getTransactionReceiptContext(ctx context.Context, txHash Hash) (*Receipt, error) {
+
+	reqBody := JSONRPCRequest{
+		JSONRPC: "2.0",
+		Method:  "eth_getTransactionReceipt",
+		Params:  []interface{}{txHash},
+	}
+
+	body, err := client.issueRequest(ctx, &reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	err = client.checkResult(body, checkReceipt)
+	if err != nil {
+		return nil, err
+	}
+
+	var clientResp ReceiptResponse
+	err = json.Unmarshal(body, &clientResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return clientResp.Result, nil
+}
+
+// Eth_getBlockReceipts calls the eth_getBlockReceipts JSON-RPC method.
+// It returns nil Receipts for unknown blocks.
+func (client *EthereumClient) Eth_getBlockReceipts(block BlockNumberOrTag) ([]Receipt, error) {
+	return client.Eth_getBlockReceiptsContext(context.Background(), block)
+}
+
+// Eth_getBlockReceiptsContext calls the eth_getBlockReceipts JSON-RPC method with the given context
+func (client *EthereumClient) Eth_getBlockReceiptsContext(ctx context.Context, block BlockNumberOrTag) ([]Receipt, error) {
+
+	reqBody := JSONRPCRequest{
+		JSONRPC: "2.0",
+		Method:  "eth_getBlockReceipts",
+		Params:  []interface{}{block},
+	}
+
+	body, err := client.issueRequest(ctx, &reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	err = client.checkResult(body, checkArray(checkReceipt))
+	if err != nil {
+		return nil, err
+	}
+
+	var clientResp BlockReceiptsResponse
+	err = json.Unmarshal(body, &clientResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return clientResp.Result, nil
+}
+
+// Eth_getLogs calls the eth_getLogs JSON-RPC method
+func (client *EthereumClient) Eth_getLogs(q FilterQuery) ([]Log, error) {
+	return client.Eth_getLogsContext(context.Background(), q)
+}
+
+// Eth_getLogsContext calls the eth_getLogs JSON-RPC method with the given context
+func (client *EthereumClient) Eth_getLogsContext(ctx context.Context, q FilterQuery) ([]Log, error) {
+
+	reqBody := JSONRPCRequest{
+		JSONRPC: "2.0",
+		Method:  "eth_getLogs",
+		Params:  []interface{}{q},
+	}
+
+	body, err := client.issueRequest(ctx, &reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	err = client.checkResult(body, checkArray(checkLog))
+	if err != nil {
+		return nil, err
+	}
+
+	var clientResp LogsResponse
+	err = json.Unmarshal(body, &clientResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return clientResp.Result, nil
+}
+
+// Eth_newFilter calls the eth_newFilter JSON-RPC method
+func (client *EthereumClient) Eth_newFilter(q FilterQuery) (string, error) {
+	return client.Eth_newFilterContext(context.Background(), q)
+}
+
+// Eth_newFilterContext calls the eth_newFilter JSON-RPC method with the given context
+func (client *EthereumClient) Eth_newFilterContext(ctx context.Context, q FilterQuery) (string, error) {
+
+	reqBody := JSONRPCRequest{
+		JSONRPC: "2.0",
+		Method:  "eth_newFilter",
+		Params:  []interface{}{q},
+	}
+
+	body, err := client.issueRequest(ctx, &reqBody)
+	if err != nil {
+		return "", err
+	}
+
+	var clientResp NewFilterResponse
+	err = json.Unmarshal(body, &clientResp)
+	if err != nil {
+		return "", err
+	}
+
+	return clientResp.Result, nil
+}
+
+// Eth_getFilterLogs calls the eth_getFilterLogs JSON-RPC method
+func (client *EthereumClient) Eth_getFilterLogs(filterID string) ([]Log, error) {
+	return client.Eth_getFilterLogsContext(context.Background(), filterID)
+}
+
+// Eth_getFilterLogsContext calls the eth_getFilterLogs JSON-RPC method with the given context
+func (client *EthereumClient) Eth_getFilterLogsContext(ctx context.Context, filterID string) ([]Log, error) {
+
+	reqBody := JSONRPCRequest{
+		JSONRPC: "2.0",
+		Method:  "eth_getFilterLogs",
+		Params:  []interface{}{filterID},
+	}
+
+	body, err := client.issueRequest(ctx, &reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	err = client.checkResult(body, checkArray(checkLog))
+	if err != nil {
+		return nil, err
+	}
+
+	var clientResp LogsResponse
+	err = json.Unmarshal(body, &clientResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return clientResp.Result, nil
+}
+
+// Eth_uninstallFilter calls the eth_uninstallFilter JSON-RPC method
+func (client *EthereumClient) Eth_uninstallFilter(filterID string) (bool, error) {
+	return client.Eth_uninstallFilterContext(context.Background(), filterID)
+}
+
+// Eth_uninstallFilterContext calls the eth_uninstallFilter JSON-RPC method with the given context
+func (client *EthereumClient) Eth_uninstallFilterContext(ctx context.Context, filterID string) (bool, error) {
+
+	reqBody := JSONRPCRequest{
+		JSONRPC: "2.0",
+		Method:  "eth_uninstallFilter",
+		Params:  []interface{}{filterID},
+	}
+
+	body, err := client.issueRequest(ctx, &reqBody)
 	if err != nil {
 		return false, err
 	}
@@ -287,3 +702,184 @@ func (client *EthereumClient) Eth_syncing() (bool, error) {
 
 	return clientResp.Result, nil
 }
+
+// Eth_sendRawTransaction calls the eth_sendRawTransaction JSON-RPC method
+// with a signed transaction in its consensus encoding and returns its hash
+func (client *EthereumClient) Eth_sendRawTransaction(rawTx []byte) (Hash, error) {
+	return client.Eth_sendRawTransactionContext(context.Background(), rawTx)
+}
+
+// Eth_sendRawTransactionContext calls the eth_sendRawTransaction JSON-RPC
+// method with the given context
+func (client *EthereumClient) Eth_sendRawTransactionContext(ctx context.Context, rawTx []byte) (Hash, error) {
+
+	reqBody := JSONRPCRequest{
+		JSONRPC: "2.0",
+		Method:  "eth_sendRawTransaction",
+		Params:  []interface{}{HexBytes(rawTx)},
+	}
+
+	body, err := client.issueRequest(ctx, &reqBody)
+	if err != nil {
+		return Hash{}, err
+	}
+
+	var clientResp HashResponse
+	err = json.Unmarshal(body, &clientResp)
+	if err != nil {
+		return Hash{}, err
+	}
+
+	return clientResp.Result, nil
+}
+
+// GetFilterChanges calls the eth_getFilterChanges JSON-RPC method for any
+// kind of filter, decoding hashes or logs depending on what the filter returns
+func (client *EthereumClient) GetFilterChanges(filterID string) (*FilterChanges, error) {
+	return client.GetFilterChangesContext(context.Background(), filterID)
+}
+
+// GetFilterChangesContext calls the eth_getFilterChanges JSON-RPC method for
+// any kind of filter with the given context
+func (client *EthereumClient) GetFilterChangesContext(ctx context.Context, filterID string) (*FilterChanges, error) {
+
+	reqBody := JSONRPCRequest{
+		JSONRPC: "2.0",
+		Method:  "eth_getFilterChanges",
+		Params:  []interface{}{filterID},
+	}
+
+	body, err := client.issueRequest(ctx, &reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	err = client.checkResult(body, checkArray(checkHashOr(checkLog)))
+	if err != nil {
+		return nil, err
+	}
+
+	var clientResp FilterChangesResponse
+	err = json.Unmarshal(body, &clientResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &clientResp.Result, nil
+}
+
+// Eth_getBalance calls the eth_getBalance JSON-RPC method and returns
+// the balance of address in wei at the given block
+func (client *EthereumClient) Eth_getBalance(address Address, block BlockNumberOrTag) (*big.Int, error) {
+	return client.Eth_getBalanceContext(context.Background(), address, block)
+}
+
+// Eth_getBalanceContext calls the eth_getBalance JSON-RPC method with the given context
+func (client *EthereumClient) Eth_getBalanceContext(ctx context.Context, address Address, block BlockNumberOrTag) (*big.Int, error) {
+
+	reqBody := JSONRPCRequest{
+		JSONRPC: "2.0",
+		Method:  "eth_getBalance",
+		Params:  []interface{}{address, block},
+	}
+
+	body, err := client.issueRequest(ctx, &reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	var clientResp BigIntResponse
+	err = json.Unmarshal(body, &clientResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return clientResp.Result.ToInt(), nil
+}
+
+// Eth_getTransactionCount calls the eth_getTransactionCount JSON-RPC method and
+// returns the nonce of address at the given block
+func (client *EthereumClient) Eth_getTransactionCount(address Address, block BlockNumberOrTag) (uint64, error) {
+	return client.Eth_getTransactionCountContext(context.Background(), address, block)
+}
+
+// Eth_getTransactionCountContext calls the eth_getTransactionCount JSON-RPC method with the given context
+func (client *EthereumClient) Eth_getTransactionCountContext(ctx context.Context, address Address, block BlockNumberOrTag) (uint64, error) {
+
+	reqBody := JSONRPCRequest{
+		JSONRPC: "2.0",
+		Method:  "eth_getTransactionCount",
+		Params:  []interface{}{address, block},
+	}
+
+	body, err := client.issueRequest(ctx, &reqBody)
+	if err != nil {
+		return 0, err
+	}
+
+	var clientResp Uint64Response
+	err = json.Unmarshal(body, &clientResp)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(clientResp.Result), nil
+}
+
+// Eth_getCode calls the eth_getCode JSON-RPC method and returns the
+// code of address at the given block, empty for accounts without code
+func (client *EthereumClient) Eth_getCode(address Address, block BlockNumberOrTag) ([]byte, error) {
+	return client.Eth_getCodeContext(context.Background(), address, block)
+}
+
+// Eth_getCodeContext calls the eth_getCode JSON-RPC method with the given context
+func (client *EthereumClient) Eth_getCodeContext(ctx context.Context, address Address, block BlockNumberOrTag) ([]byte, error) {
+
+	reqBody := JSONRPCRequest{
+		JSONRPC: "2.0",
+		Method:  "eth_getCode",
+		Params:  []interface{}{address, block},
+	}
+
+	body, err := client.issueRequest(ctx, &reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	var clientResp BytesResponse
+	err = json.Unmarshal(body, &clientResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return clientResp.Result, nil
+}
+
+// Eth_getStorageAt calls the eth_getStorageAt JSON-RPC method and returns
+// the value of the storage slot of address at the given block
+func (client *EthereumClient) Eth_getStorageAt(address Address, slot Hash, block BlockNumberOrTag) (Hash, error) {
+	return client.Eth_getStorageAtContext(context.Background(), address, slot, block)
+}
+
+// Eth_getStorageAtContext calls the eth_getStorageAt JSON-RPC method with the given context
+func (client *EthereumClient) Eth_getStorageAtContext(ctx context.Context, address Address, slot Hash, block BlockNumberOrTag) (Hash, error) {
+
+	reqBody := JSONRPCRequest{
+		JSONRPC: "2.0",
+		Method:  "eth_getStorageAt",
+		Params:  []interface{}{address, slot, block},
+	}
+
+	body, err := client.issueRequest(ctx, &reqBody)
+	if err != nil {
+		return Hash{}, err
+	}
+
+	var clientResp HashResponse
+	err = json.Unmarshal(body, &clientResp)
+	if err != nil {
+		return Hash{}, err
+	}
+
+	return clientResp.Result, nil
+}
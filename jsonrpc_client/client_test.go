@@ -0,0 +1,213 @@
+package jsonrpc_client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// blockNumberHandler answers eth_blockNumber with 16
+func blockNumberHandler(method string, params []json.RawMessage) (interface{}, error) {
+	return hexResult(16), nil
+}
+
+func TestClientHeaders(t *testing.T) {
+
+	headers := make(chan http.Header, 1)
+	rpc := rpcHTTPHandler(blockNumberHandler)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers <- r.Header.Clone()
+		rpc(w, r)
+	}))
+	defer server.Close()
+
+	client := NewEthereumClient(server.URL, WithHeader("X-Api-Key", "key"), WithBasicAuth("", "secret"))
+	number, err := client.Eth_blockNumber()
+	if err != nil || number != 16 {
+		t.Fatalf("Eth_blockNumber() = %d, %v, want 16", number, err)
+	}
+
+	header := <-headers
+	if got := header.Get("Content-Type"); got != JSON_MEDIA_TYPE {
+		t.Errorf("Content-Type = %q, want %q", got, JSON_MEDIA_TYPE)
+	}
+	if got := header.Get("X-Api-Key"); got != "key" {
+		t.Errorf("X-Api-Key = %q, want %q", got, "key")
+	}
+	r := http.Request{Header: header}
+	if username, password, ok := r.BasicAuth(); !ok || username != "" || password != "secret" {
+		t.Errorf("BasicAuth() = %q, %q, %t", username, password, ok)
+	}
+}
+
+// countingTransport counts the requests it forwards
+type countingTransport struct {
+	requests int32
+}
+
+// RoundTrip implements http.RoundTripper
+func (transport *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	atomic.AddInt32(&transport.requests, 1)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestClientHTTPClient(t *testing.T) {
+	server := newRPCServer(t, blockNumberHandler)
+	transport := &countingTransport{}
+	client := NewEthereumClient(server.URL, WithHTTPClient(&http.Client{Transport: transport}))
+	if _, err := client.Eth_blockNumber(); err != nil {
+		t.Fatal(err)
+	}
+	if transport.requests != 1 {
+		t.Errorf("%d requests through the http.Client, want 1", transport.requests)
+	}
+}
+
+func TestClientContext(t *testing.T) {
+
+	// the server never answers
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewEthereumClient(server.URL)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := client.Eth_blockNumberContext(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) || time.Since(start) > 2*time.Second {
+		t.Errorf("Eth_blockNumberContext() = %v after %v, want the context deadline", err, time.Since(start))
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.Eth_blockNumberContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Eth_blockNumberContext() = %v, want %v", err, context.Canceled)
+	}
+
+	client = NewEthereumClient(server.URL, WithTimeout(50*time.Millisecond))
+	if _, err := client.Eth_blockNumber(); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Eth_blockNumber() = %v, want the client timeout", err)
+	}
+}
+
+func TestClientCall(t *testing.T) {
+
+	var rawParams []string
+	handler := rpcHTTPHandler(func(method string, params []json.RawMessage) (interface{}, error) {
+		switch method {
+		case "eth_chainId":
+			return "0x1", nil
+		case "debug_traceTransaction":
+			return map[string]interface{}{"gas": 21000, "failed": false, "returnValue": "", "structLogs": []interface{}{}}, nil
+		case "vendor_echo":
+			return params, nil
+		}
+		return nil, &RPCError{Code: METHOD_NOT_FOUND_CODE, Message: "method not found"}
+	})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct{ Params json.RawMessage }
+		body, _ := ioutil.ReadAll(r.Body)
+		json.Unmarshal(body, &req)
+		rawParams = append(rawParams, string(req.Params))
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+		handler(w, r)
+	}))
+	defer server.Close()
+	client := NewEthereumClient(server.URL)
+	ctx := context.Background()
+
+	// results decode into any type json.Unmarshal supports
+	var chainID HexUint64
+	err := client.Call(ctx, "eth_chainId", &chainID)
+	if err != nil || chainID != 1 {
+		t.Errorf("Call(eth_chainId) = %d, %v", chainID, err)
+	}
+	// methods without params send an empty array, not null
+	if rawParams[0] != "[]" {
+		t.Errorf("sent params %s, want []", rawParams[0])
+	}
+
+	var trace struct {
+		Gas    uint64 `json:"gas"`
+		Failed bool   `json:"failed"`
+	}
+	err = client.Call(ctx, "debug_traceTransaction", &trace, Hash{1}, map[string]string{"tracer": "callTracer"})
+	if err != nil || trace.Gas != 21000 || trace.Failed {
+		t.Errorf("Call(debug_traceTransaction) = %+v, %v", trace, err)
+	}
+
+	var echoed []interface{}
+	err = client.Call(ctx, "vendor_echo", &echoed, BlockAtNumber(16), true, Address{19: 1})
+	if err != nil || len(echoed) != 3 || echoed[0] != "0x10" || echoed[1] != true || echoed[2] != "0x0000000000000000000000000000000000000001" {
+		t.Errorf("Call(vendor_echo) = %v, %v", echoed, err)
+	}
+
+	// the result may be discarded
+	err = client.Call(ctx, "eth_chainId", nil)
+	if err != nil {
+		t.Errorf("Call(nil result) = %v", err)
+	}
+
+	var wrongType bool
+	err = client.Call(ctx, "eth_chainId", &wrongType)
+	if err == nil {
+		t.Errorf("Call() decoded a string into a bool")
+	}
+	err = client.Call(ctx, "vendor_unknown", nil)
+	if !IsMethodNotFound(err) {
+		t.Errorf("Call(vendor_unknown) = %v, want method not found", err)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	err = client.Call(cancelled, "eth_chainId", &chainID)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Call(cancelled) = %v, want context.Canceled", err)
+	}
+}
+
+func TestClientEmptyParams(t *testing.T) {
+
+	var bodies []string
+	handler := rpcHTTPHandler(func(method string, params []json.RawMessage) (interface{}, error) {
+		if method == "eth_syncing" {
+			return false, nil
+		}
+		return "0x1", nil
+	})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+		handler(w, r)
+	}))
+	defer server.Close()
+	client := NewEthereumClient(server.URL)
+
+	// some nodes reject a null or missing params member
+	client.Eth_newBlockFilter()
+	client.Eth_newPendingTransactionFilter()
+	client.Eth_blockNumber()
+	client.Web3_clientVersion()
+	client.Eth_syncing()
+	if len(bodies) != 5 {
+		t.Fatalf("sent %d requests, want 5", len(bodies))
+	}
+	for _, body := range bodies {
+		if !strings.Contains(body, `"params":[]`) {
+			t.Errorf("sent %s, want empty params", body)
+		}
+	}
+}
@@ -1,5 +1,64 @@
 package jsonrpc_client
 
+import (
+	"time"
+)
+
 const (
 	JSON_MEDIA_TYPE = "application/json"
 )
+
+// HTTP transport settings
+const (
+	MAX_RESPONSE_SIZE            = 128 * 1024 * 1024
+	TRANSPORT_ERROR_BODY_SNIPPET = 512 // bytes of the body kept by a TransportError
+)
+
+// WebSocket transport settings
+const (
+	WS_MAX_MESSAGE_SIZE      = 128 * 1024 * 1024
+	WS_SUBSCRIPTION_BUFFER   = 4096 // notifications queued per subscription
+	WS_PING_INTERVAL         = 30 * time.Second
+	WS_HANDSHAKE_TIMEOUT     = 30 * time.Second
+	WS_RECONNECT_MIN_BACKOFF = 100 * time.Millisecond
+	WS_RECONNECT_MAX_BACKOFF = 30 * time.Second
+)
+
+// Default retry policy
+const (
+	RETRY_MAX_ATTEMPTS = 4
+	RETRY_MIN_BACKOFF  = 250 * time.Millisecond
+	RETRY_MAX_BACKOFF  = 10 * time.Second
+)
+
+// Failover settings
+const (
+	FAILOVER_HEALTH_CHECK_INTERVAL = 15 * time.Second
+	FAILOVER_HEALTH_CHECK_TIMEOUT  = 5 * time.Second  // for each endpoint
+	FAILOVER_CALL_TIMEOUT          = 30 * time.Second // for each endpoint tried by a call, without WithTimeout
+	FAILOVER_MAX_BLOCK_LAG         = 5                // blocks behind the highest endpoint before being deprioritized
+)
+
+// Transaction types (EIP-2718)
+const (
+	LEGACY_TX_TYPE      = 0x00
+	ACCESS_LIST_TX_TYPE = 0x01 // EIP-2930
+	DYNAMIC_FEE_TX_TYPE = 0x02 // EIP-1559
+	BLOB_TX_TYPE        = 0x03 // EIP-4844
+	SET_CODE_TX_TYPE    = 0x04 // EIP-7702
+)
+
+// SET_CODE_AUTH_MAGIC prefixes the signed payload of EIP-7702 authorizations
+const SET_CODE_AUTH_MAGIC = 0x05
+
+// JSON-RPC error codes
+const (
+	PARSE_ERROR_CODE        = -32700
+	INVALID_REQUEST_CODE    = -32600
+	METHOD_NOT_FOUND_CODE   = -32601
+	INVALID_PARAMS_CODE     = -32602
+	INTERNAL_ERROR_CODE     = -32603
+	SERVER_ERROR_CODE       = -32000 // generic node error, e.g. "header not found"
+	LIMIT_EXCEEDED_CODE     = -32005 // Infura rate limiting
+	EXECUTION_REVERTED_CODE = 3      // eth_call and eth_estimateGas reverts
+)
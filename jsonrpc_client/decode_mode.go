@@ -0,0 +1,414 @@
+package jsonrpc_client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// DecodeMode selects how thoroughly node responses are checked when decoded
+type DecodeMode int
+
+const (
+	// LenientDecoding leaves missing and null fields zero or nil, and fails
+	// on the first malformed value
+	LenientDecoding DecodeMode = iota
+	// StrictDecoding checks the whole response before decoding it, and
+	// reports every malformed field and every missing field that nodes
+	// always return in a single *DecodeError
+	StrictDecoding
+)
+
+// FieldError is a malformed or missing field of a node response
+type FieldError struct {
+	Path string // JSON path, e.g. "result.transactions[2].gasPrice"
+	Err  error
+}
+
+// Error implements the error interface
+func (fieldErr *FieldError) Error() string {
+	return fmt.Sprintf("%s: %v", fieldErr.Path, fieldErr.Err)
+}
+
+// Unwrap returns the underlying error
+func (fieldErr *FieldError) Unwrap() error {
+	return fieldErr.Err
+}
+
+// DecodeError lists every malformed or missing field of a node response
+// found in strict decoding mode
+type DecodeError struct {
+	Fields []*FieldError
+}
+
+// Error implements the error interface
+func (decErr *DecodeError) Error() string {
+	messages := make([]string, len(decErr.Fields))
+	for i, fieldErr := range decErr.Fields {
+		messages[i] = fieldErr.Error()
+	}
+	return fmt.Sprintf("%d invalid fields: %s", len(decErr.Fields), strings.Join(messages, "; "))
+}
+
+// DecodeBlock decodes a block in the JSON-RPC form with the given mode.
+// It returns a nil Block for null.
+func DecodeBlock(b []byte, mode DecodeMode) (*Block, error) {
+	var block *Block
+	err := decodeWithMode(b, mode, checkBlock, &block)
+	if err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+// DecodeTransaction decodes a transaction in the JSON-RPC form with the
+// given mode. It returns a nil Transaction for null.
+func DecodeTransaction(b []byte, mode DecodeMode) (*Transaction, error) {
+	var tx *Transaction
+	err := decodeWithMode(b, mode, checkTransaction, &tx)
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// decodeWithMode unmarshals b into v, checking it first in strict mode
+func decodeWithMode(b []byte, mode DecodeMode, check valueCheck, v interface{}) error {
+	if mode == StrictDecoding {
+		err := checkValue(b, "", check)
+		if err != nil {
+			return err
+		}
+	}
+	return json.Unmarshal(b, v)
+}
+
+// checkResult checks the result of a response in strict decoding mode
+func (client *EthereumClient) checkResult(body []byte, check valueCheck) error {
+	if client.decodeMode != StrictDecoding {
+		return nil
+	}
+	var resp RawResponse
+	err := json.Unmarshal(body, &resp)
+	if err != nil {
+		return err
+	}
+	return checkValue(resp.Result, "result", check)
+}
+
+// checkValue checks a JSON value and returns a *DecodeError listing every
+// field failing its check. Null values pass.
+func checkValue(value json.RawMessage, path string, check valueCheck) error {
+	if isNull(value) {
+		return nil
+	}
+	checker := fieldChecker{}
+	check(&checker, path, value)
+	if len(checker.errs) > 0 {
+		return &DecodeError{Fields: checker.errs}
+	}
+	return nil
+}
+
+// fieldChecker collects the errors found while checking a response
+type fieldChecker struct {
+	errs []*FieldError
+}
+
+// fail records an error at path
+func (checker *fieldChecker) fail(path string, err error) {
+	checker.errs = append(checker.errs, &FieldError{Path: path, Err: err})
+}
+
+// valueCheck checks a non-null JSON value found at path
+type valueCheck func(checker *fieldChecker, path string, value json.RawMessage)
+
+// fieldRule checks a field of a JSON object
+type fieldRule struct {
+	name     string
+	present  bool // the field must be present
+	nullable bool // the field may be null
+	check    valueCheck
+}
+
+// requiredField is a field that must be present and not null
+func requiredField(name string, check valueCheck) fieldRule {
+	return fieldRule{name: name, present: true, check: check}
+}
+
+// nullableField is a field that must be present but may be null, e.g. the block
+// hash of pending transactions
+func nullableField(name string, check valueCheck) fieldRule {
+	return fieldRule{name: name, present: true, nullable: true, check: check}
+}
+
+// optionalField is a field that may be absent or null, e.g. one added by a fork
+func optionalField(name string, check valueCheck) fieldRule {
+	return fieldRule{name: name, nullable: true, check: check}
+}
+
+// isNull reports whether a JSON value is null
+func isNull(value json.RawMessage) bool {
+	return bytes.Equal(bytes.TrimSpace(value), []byte("null"))
+}
+
+// joinPath returns the path of the field name within path
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// checkObject checks a JSON object against rules. Unknown fields are ignored.
+func checkObject(rules []fieldRule) valueCheck {
+	return func(checker *fieldChecker, path string, value json.RawMessage) {
+		var fields map[string]json.RawMessage
+		err := json.Unmarshal(value, &fields)
+		if err != nil {
+			checker.fail(path, fmt.Errorf("expected an object, got %s", snippet(value)))
+			return
+		}
+		for _, rule := range rules {
+			fieldPath := joinPath(path, rule.name)
+			fieldValue, ok := fields[rule.name]
+			switch {
+			case !ok:
+				if rule.present {
+					checker.fail(fieldPath, ErrMissingField)
+				}
+			case isNull(fieldValue):
+				if !rule.nullable {
+					checker.fail(fieldPath, ErrNullField)
+				}
+			default:
+				rule.check(checker, fieldPath, fieldValue)
+			}
+		}
+	}
+}
+
+// checkArray checks a JSON array whose items pass check
+func checkArray(check valueCheck) valueCheck {
+	return func(checker *fieldChecker, path string, value json.RawMessage) {
+		var items []json.RawMessage
+		err := json.Unmarshal(value, &items)
+		if err != nil {
+			checker.fail(path, fmt.Errorf("expected an array, got %s", snippet(value)))
+			return
+		}
+		for i, item := range items {
+			itemPath := fmt.Sprintf("%s[%d]", path, i)
+			if isNull(item) {
+				checker.fail(itemPath, ErrNullField)
+				continue
+			}
+			check(checker, itemPath, item)
+		}
+	}
+}
+
+// checkHashOr checks a hash, or a JSON object passing check, e.g. the
+// transactions of a block fetched with or without full transactions
+func checkHashOr(check valueCheck) valueCheck {
+	return func(checker *fieldChecker, path string, value json.RawMessage) {
+		if bytes.HasPrefix(bytes.TrimSpace(value), []byte(`"`)) {
+			checkHash(checker, path, value)
+			return
+		}
+		check(checker, path, value)
+	}
+}
+
+// checkText checks a JSON string with parse
+func checkText(parse func(s string) error) valueCheck {
+	return func(checker *fieldChecker, path string, value json.RawMessage) {
+		var s string
+		err := json.Unmarshal(value, &s)
+		if err != nil {
+			checker.fail(path, fmt.Errorf("expected a string, got %s", snippet(value)))
+			return
+		}
+		err = parse(s)
+		if err != nil {
+			checker.fail(path, err)
+		}
+	}
+}
+
+// checkBool checks a JSON boolean
+func checkBool(checker *fieldChecker, path string, value json.RawMessage) {
+	var b bool
+	err := json.Unmarshal(value, &b)
+	if err != nil {
+		checker.fail(path, fmt.Errorf("expected a boolean, got %s", snippet(value)))
+	}
+}
+
+// checkAny accepts any JSON value
+func checkAny(checker *fieldChecker, path string, value json.RawMessage) {}
+
+// snippet returns the start of a JSON value for error messages
+func snippet(value json.RawMessage) string {
+	value = bytes.TrimSpace(value)
+	if len(value) > 32 {
+		return string(value[:32]) + "..."
+	}
+	return string(value)
+}
+
+var (
+	checkQuantity = checkText(func(s string) error {
+		_, err := ParseHexUint64(s)
+		return err
+	})
+	checkBigQuantity = checkText(func(s string) error {
+		_, err := ParseHexBig(s)
+		return err
+	})
+	checkBytes = checkText(func(s string) error {
+		_, err := ParseHexBytes(s)
+		return err
+	})
+	checkHash = checkText(func(s string) error {
+		_, err := ParseHash(s)
+		return err
+	})
+	checkAddress = checkText(func(s string) error {
+		_, err := ParseAddress(s)
+		return err
+	})
+	checkBloom = checkText(func(s string) error {
+		_, err := ParseBloom(s)
+		return err
+	})
+	// block nonces are 8 bytes, not quantities
+	checkBlockNonce = checkText(func(s string) error {
+		b, err := ParseHexBytes(s)
+		if err == nil && len(b) != 8 {
+			err = fmt.Errorf("nonce %q is not 8 bytes", s)
+		}
+		return err
+	})
+)
+
+var checkWithdrawal = checkObject([]fieldRule{
+	requiredField("index", checkQuantity),
+	requiredField("validatorIndex", checkQuantity),
+	requiredField("address", checkAddress),
+	requiredField("amount", checkQuantity),
+})
+
+var checkAccessTuple = checkObject([]fieldRule{
+	requiredField("address", checkAddress),
+	requiredField("storageKeys", checkArray(checkHash)),
+})
+
+var checkAuthorization = checkObject([]fieldRule{
+	requiredField("chainId", checkBigQuantity),
+	requiredField("address", checkAddress),
+	requiredField("nonce", checkQuantity),
+	requiredField("yParity", checkQuantity),
+	requiredField("r", checkBigQuantity),
+	requiredField("s", checkBigQuantity),
+})
+
+var checkTransaction = checkObject([]fieldRule{
+	nullableField("blockHash", checkHash),
+	nullableField("blockNumber", checkQuantity),
+	requiredField("from", checkAddress),
+	requiredField("gas", checkQuantity),
+	requiredField("gasPrice", checkBigQuantity),
+	requiredField("hash", checkHash),
+	requiredField("input", checkBytes),
+	requiredField("nonce", checkQuantity),
+	requiredField("r", checkBigQuantity),
+	requiredField("s", checkBigQuantity),
+	nullableField("to", checkAddress),
+	nullableField("transactionIndex", checkQuantity),
+	requiredField("v", checkBigQuantity),
+	requiredField("value", checkBigQuantity),
+
+	optionalField("chainId", checkBigQuantity),
+	optionalField("type", checkQuantity),
+	optionalField("accessList", checkArray(checkAccessTuple)),
+	optionalField("maxFeePerGas", checkBigQuantity),
+	optionalField("maxPriorityFeePerGas", checkBigQuantity),
+	optionalField("yParity", checkQuantity),
+	optionalField("maxFeePerBlobGas", checkBigQuantity),
+	optionalField("blobVersionedHashes", checkArray(checkHash)),
+	optionalField("authorizationList", checkArray(checkAuthorization)),
+
+	optionalField("condition", checkAny),
+	optionalField("creates", checkAddress),
+	optionalField("publicKey", checkBytes),
+	optionalField("raw", checkBytes),
+	optionalField("standardV", checkQuantity),
+})
+
+var checkBlock = checkObject([]fieldRule{
+	optionalField("author", checkAddress),
+	requiredField("difficulty", checkBigQuantity),
+	requiredField("extraData", checkBytes),
+	requiredField("gasLimit", checkQuantity),
+	requiredField("gasUsed", checkQuantity),
+	nullableField("hash", checkHash), // null for pending block
+	requiredField("logsBloom", checkBloom),
+	nullableField("miner", checkAddress), // null for pending block
+	optionalField("mixHash", checkHash),
+	nullableField("nonce", checkBlockNonce), // null for pending block
+	nullableField("number", checkQuantity),  // null for pending block
+	requiredField("parentHash", checkHash),
+	requiredField("receiptsRoot", checkHash),
+	optionalField("sealFields", checkArray(checkBytes)),
+	requiredField("sha3Uncles", checkHash),
+	optionalField("size", checkQuantity), // absent from newHeads notifications
+	requiredField("stateRoot", checkHash),
+	requiredField("timestamp", checkQuantity),
+	optionalField("totalDifficulty", checkBigQuantity), // dropped after the Merge by some nodes
+	requiredField("transactions", checkArray(checkHashOr(checkTransaction))),
+	requiredField("transactionsRoot", checkHash),
+	requiredField("uncles", checkArray(checkHash)),
+
+	optionalField("baseFeePerGas", checkBigQuantity),
+	optionalField("withdrawalsRoot", checkHash),
+	optionalField("withdrawals", checkArray(checkWithdrawal)),
+	optionalField("blobGasUsed", checkQuantity),
+	optionalField("excessBlobGas", checkQuantity),
+	optionalField("parentBeaconBlockRoot", checkHash),
+	optionalField("requestsHash", checkHash),
+})
+
+var checkLog = checkObject([]fieldRule{
+	requiredField("address", checkAddress),
+	requiredField("topics", checkArray(checkHash)),
+	requiredField("data", checkBytes),
+	nullableField("blockHash", checkHash),
+	nullableField("blockNumber", checkQuantity),
+	nullableField("transactionHash", checkHash),
+	nullableField("transactionIndex", checkQuantity),
+	nullableField("logIndex", checkQuantity),
+	optionalField("removed", checkBool),
+})
+
+var checkReceipt = checkObject([]fieldRule{
+	requiredField("blockHash", checkHash),
+	requiredField("blockNumber", checkQuantity),
+	nullableField("contractAddress", checkAddress),
+	requiredField("cumulativeGasUsed", checkQuantity),
+	optionalField("effectiveGasPrice", checkBigQuantity),
+	requiredField("from", checkAddress),
+	requiredField("gasUsed", checkQuantity),
+	requiredField("logs", checkArray(checkLog)),
+	requiredField("logsBloom", checkBloom),
+	optionalField("root", checkHash),
+	optionalField("status", checkQuantity),
+	nullableField("to", checkAddress),
+	requiredField("transactionHash", checkHash),
+	requiredField("transactionIndex", checkQuantity),
+	optionalField("type", checkQuantity),
+
+	optionalField("blobGasUsed", checkQuantity),
+	optionalField("blobGasPrice", checkBigQuantity),
+})
@@ -0,0 +1,152 @@
+package jsonrpc_client
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// fieldPaths returns the paths of the fields of a *DecodeError
+func fieldPaths(t *testing.T, err error) []string {
+	t.Helper()
+	var decErr *DecodeError
+	if !errors.As(err, &decErr) {
+		t.Fatalf("got %v, want a *DecodeError", err)
+	}
+	paths := make([]string, len(decErr.Fields))
+	for i, fieldErr := range decErr.Fields {
+		paths[i] = fieldErr.Path
+	}
+	return paths
+}
+
+func TestDecodeStrictValid(t *testing.T) {
+
+	for _, s := range []string{mainnetGenesis, mainnetBlock1, sepoliaGenesis, hoodiGenesis} {
+		if _, err := DecodeBlock([]byte(s), StrictDecoding); err != nil {
+			t.Errorf("DecodeBlock(): %v", err)
+		}
+	}
+	for _, s := range []string{frontierTransaction, blobTransaction, setCodeTransaction} {
+		if _, err := DecodeTransaction([]byte(s), StrictDecoding); err != nil {
+			t.Errorf("DecodeTransaction(): %v", err)
+		}
+	}
+
+	block, err := DecodeBlock([]byte("null"), StrictDecoding)
+	if err != nil || block != nil {
+		t.Errorf("DecodeBlock(null) = %v, %v, want nil", block, err)
+	}
+}
+
+func TestDecodeStrictErrors(t *testing.T) {
+
+	// every malformed and missing field is reported with its path
+	tx := strings.Replace(frontierTransaction, `"gasPrice": "0x2d79883d2000"`, `"gasPrice": "garbage"`, 1)
+	tx = strings.Replace(tx, `"value": "0x7a69"`, `"other": "0x7a69"`, 1)
+	tx = strings.Replace(tx, `"nonce": "0x0"`, `"nonce": null`, 1)
+	s := strings.Replace(mainnetBlock1, `"transactions": []`, `"transactions": [`+frontierTransaction+`,`+tx+`]`, 1)
+	s = strings.Replace(s, `"totalDifficulty": "0x7ff800000"`, `"totalDifficulty": "0x07ff800000"`, 1)
+	s = strings.Replace(s, `"nonce": "0x539bd4979fef1ec4"`, `"nonce": "0x42"`, 1)
+	if !strings.Contains(s, `"nonce": "0x42"`) {
+		t.Fatal("block nonce not replaced")
+	}
+
+	_, err := DecodeBlock([]byte(s), StrictDecoding)
+	got := strings.Join(fieldPaths(t, err), " ")
+	want := "nonce totalDifficulty transactions[1].gasPrice transactions[1].nonce transactions[1].value"
+	if got != want {
+		t.Errorf("DecodeBlock() failed %s, want %s", got, want)
+	}
+
+	var decErr *DecodeError
+	errors.As(err, &decErr)
+	if !errors.Is(decErr.Fields[4], ErrMissingField) || !errors.Is(decErr.Fields[3], ErrNullField) {
+		t.Errorf("missing and null fields reported as %v and %v", decErr.Fields[4].Err, decErr.Fields[3].Err)
+	}
+	if !strings.HasPrefix(err.Error(), "5 invalid fields: ") {
+		t.Errorf("Error() = %q", err.Error())
+	}
+
+	// the lenient mode fails on the first malformed value
+	_, err = DecodeBlock([]byte(s), LenientDecoding)
+	if err == nil || errors.As(err, &decErr) {
+		t.Errorf("DecodeBlock(lenient) = %v, want a plain decoding error", err)
+	}
+}
+
+func TestDecodeLenientNil(t *testing.T) {
+
+	// pending blocks and pruned responses leave big.Int fields nil, which must
+	// not panic when encoded or compared
+	s := strings.Replace(mainnetBlock1, `"nonce": "0x539bd4979fef1ec4"`, `"nonce": null`, 1)
+	s = strings.Replace(s, `"totalDifficulty": "0x7ff800000",`, ``, 1)
+	s = strings.Replace(s, `"difficulty": "0x3ff800000",`, ``, 1)
+	block, err := DecodeBlock([]byte(s), LenientDecoding)
+	if err != nil {
+		t.Fatalf("DecodeBlock(): %v", err)
+	}
+	if block.Nonce != nil || block.TotalDifficulty != nil || block.Difficulty != nil {
+		t.Errorf("missing fields decoded as %v, %v, %v", block.Nonce, block.TotalDifficulty, block.Difficulty)
+	}
+
+	b, err := json.Marshal(block)
+	if err != nil {
+		t.Fatalf("Marshal(): %v", err)
+	}
+	if !mustDecodeBlock(t, string(b)).Equals(block) {
+		t.Errorf("JSON-RPC round trip changed the block: %s", b)
+	}
+	b, err = block.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON(): %v", err)
+	}
+	stored, err := NewBlockFromJSON(b)
+	if err != nil || !stored.Equals(block) || stored.Equals(mustDecodeBlock(t, mainnetBlock1)) {
+		t.Errorf("stored round trip = %v, %v", stored, err)
+	}
+
+	var tx Transaction
+	if err := json.Unmarshal([]byte(`{"hash":"0x5c504ed432cb51138bcf09aa5e8a410dd4a1e204ef84bfed1be16dfba1b22060"}`), &tx); err != nil {
+		t.Fatalf("Unmarshal(): %v", err)
+	}
+	if _, err := json.Marshal(tx); err != nil {
+		t.Errorf("Marshal(): %v", err)
+	}
+	if _, err := tx.ToJSON(); err != nil {
+		t.Errorf("ToJSON(): %v", err)
+	}
+	if !tx.Equals(&tx) {
+		t.Errorf("Equals() is false for the same transaction")
+	}
+}
+
+func TestClientDecodeMode(t *testing.T) {
+
+	tx := strings.Replace(frontierTransaction, `"gasPrice": "0x2d79883d2000"`, `"gasPrice": "garbage"`, 1)
+	server := newRPCServer(t, func(method string, params []json.RawMessage) (interface{}, error) {
+		if method == "eth_getTransactionByHash" {
+			return json.RawMessage(tx), nil
+		}
+		return json.RawMessage(strings.Replace(mainnetBlock1, `"gasUsed": "0x0"`, `"gasUsed": 0`, 1)), nil
+	})
+
+	strict := NewEthereumClient(server.URL, WithDecodeMode(StrictDecoding))
+	_, err := strict.Eth_getTransactionByHash(Hash{1})
+	if paths := fieldPaths(t, err); len(paths) != 1 || paths[0] != "result.gasPrice" {
+		t.Errorf("Eth_getTransactionByHash() failed %v, want result.gasPrice", paths)
+	}
+	_, err = strict.GetBlockRange(1, 2, false)
+	if paths := fieldPaths(t, err); len(paths) != 1 || paths[0] != "result.gasUsed" {
+		t.Errorf("GetBlockRange() failed %v, want result.gasUsed", paths)
+	}
+
+	// the default lenient mode still rejects malformed values
+	lenient := NewEthereumClient(server.URL)
+	_, err = lenient.Eth_getTransactionByHash(Hash{1})
+	var decErr *DecodeError
+	if err == nil || errors.As(err, &decErr) {
+		t.Errorf("Eth_getTransactionByHash(lenient) = %v, want a plain decoding error", err)
+	}
+}
@@ -0,0 +1,129 @@
+package jsonrpc_client
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// FieldDiff is a field whose value differs between two responses, with both
+// values in their JSON-RPC encoding
+type FieldDiff struct {
+	Path  string // JSON path, e.g. "transactions[2].gasPrice"
+	Left  string
+	Right string
+}
+
+// String returns the field path and both values
+func (fieldDiff FieldDiff) String() string {
+	return fmt.Sprintf("%s: %s != %s", fieldDiff.Path, fieldDiff.Left, fieldDiff.Right)
+}
+
+// Diff lists the fields that differ between two Blocks, including their
+// transactions, uncles and withdrawals, e.g. to find where two providers
+// disagree. It returns an empty list for equal blocks. As in Equals, null
+// uncles equal empty ones.
+func (block *Block) Diff(block2 *Block) ([]FieldDiff, error) {
+	left, right := *block, *block2
+	if left.Uncles == nil {
+		left.Uncles = []Hash{}
+	}
+	if right.Uncles == nil {
+		right.Uncles = []Hash{}
+	}
+	return diffJSON(&left, &right)
+}
+
+// Diff lists the fields that differ between two Transactions. It returns an
+// empty list for equal transactions.
+func (tx *Transaction) Diff(tx2 *Transaction) ([]FieldDiff, error) {
+	return diffJSON(tx, tx2)
+}
+
+// diffJSON lists the fields that differ between the JSON encodings of left
+// and right
+func diffJSON(left, right interface{}) ([]FieldDiff, error) {
+
+	leftValue, err := decodeJSONTree(left)
+	if err != nil {
+		return nil, fmt.Errorf("Diff left: %v", err)
+	}
+	rightValue, err := decodeJSONTree(right)
+	if err != nil {
+		return nil, fmt.Errorf("Diff right: %v", err)
+	}
+
+	diffs := []FieldDiff{}
+	return diffValues("", leftValue, rightValue, diffs), nil
+}
+
+// decodeJSONTree returns the JSON encoding of v as maps, slices and scalars
+func decodeJSONTree(v interface{}) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var tree interface{}
+	err = json.Unmarshal(b, &tree)
+	if err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
+
+// diffValues appends the differences between two decoded JSON values found
+// at path. Objects are compared field by field, in field name order, and
+// arrays item by item.
+func diffValues(path string, left, right interface{}, diffs []FieldDiff) []FieldDiff {
+
+	leftObject, leftIsObject := left.(map[string]interface{})
+	rightObject, rightIsObject := right.(map[string]interface{})
+	if leftIsObject && rightIsObject {
+		names := make([]string, 0, len(leftObject))
+		for name := range leftObject {
+			names = append(names, name)
+		}
+		for name := range rightObject {
+			if _, ok := leftObject[name]; !ok {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			diffs = diffValues(joinPath(path, name), leftObject[name], rightObject[name], diffs)
+		}
+		return diffs
+	}
+
+	leftArray, leftIsArray := left.([]interface{})
+	rightArray, rightIsArray := right.([]interface{})
+	if leftIsArray && rightIsArray {
+		for i := 0; i < len(leftArray) || i < len(rightArray); i++ {
+			var leftItem, rightItem interface{}
+			if i < len(leftArray) {
+				leftItem = leftArray[i]
+			}
+			if i < len(rightArray) {
+				rightItem = rightArray[i]
+			}
+			diffs = diffValues(fmt.Sprintf("%s[%d]", path, i), leftItem, rightItem, diffs)
+		}
+		return diffs
+	}
+
+	leftText, rightText := diffText(left), diffText(right)
+	if leftText != rightText {
+		diffs = append(diffs, FieldDiff{Path: path, Left: leftText, Right: rightText})
+	}
+	return diffs
+}
+
+// diffText returns a decoded JSON value as displayed in a FieldDiff: strings
+// as they are, and other values in their JSON encoding
+func diffText(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
@@ -0,0 +1,73 @@
+package jsonrpc_client
+
+import (
+	"testing"
+)
+
+func TestBlockEqualsUncles(t *testing.T) {
+
+	block := mustDecodeBlock(t, mainnetBlock1)
+	block2 := mustDecodeBlock(t, mainnetBlock1)
+	block2.Uncles = nil
+
+	// null and empty uncles are the same
+	if !block.Equals(block2) || !block2.Equals(block) {
+		t.Errorf("Equals() distinguished null and empty uncles")
+	}
+	diffs, err := block.Diff(block2)
+	if err != nil || len(diffs) != 0 {
+		t.Errorf("Diff() = %v, %v, want no differences", diffs, err)
+	}
+
+	block2.Uncles = []Hash{{1}}
+	if block.Equals(block2) {
+		t.Errorf("Equals() ignored an uncle")
+	}
+	diffs, err = block.Diff(block2)
+	if err != nil || len(diffs) != 1 || diffs[0].Path != "uncles[0]" {
+		t.Errorf("Diff() = %v, %v, want uncles[0]", diffs, err)
+	}
+}
+
+func TestBlockEqualsWithdrawals(t *testing.T) {
+
+	// withdrawals are null before Shanghai, unlike an empty list after
+	block := mustDecodeBlock(t, hoodiGenesis)
+	block2 := mustDecodeBlock(t, hoodiGenesis)
+	block2.Withdrawals = nil
+	if block.Equals(block2) {
+		t.Errorf("Equals() ignored null withdrawals")
+	}
+	diffs, err := block.Diff(block2)
+	if err != nil || len(diffs) != 1 || diffs[0].Path != "withdrawals" {
+		t.Errorf("Diff() = %v, %v, want withdrawals", diffs, err)
+	}
+}
+
+func TestTransactionDiff(t *testing.T) {
+
+	tx := mustDecodeTransaction(t, frontierTransaction)
+	tx2 := mustDecodeTransaction(t, frontierTransaction)
+	if !tx.Equals(tx2) {
+		t.Errorf("Equals() = false for equal transactions")
+	}
+
+	tx2.Gas++
+	tx2.Value.SetInt64(1)
+	diffs, err := tx.Diff(tx2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []FieldDiff{{"gas", "0x5208", "0x5209"}, {"value", "0x7a69", "0x1"}}
+	if len(diffs) != len(want) {
+		t.Fatalf("Diff() = %v, want %v", diffs, want)
+	}
+	for i := range want {
+		if diffs[i] != want[i] {
+			t.Errorf("Diff()[%d] = %v, want %v", i, diffs[i], want[i])
+		}
+	}
+	if tx.Equals(tx2) {
+		t.Errorf("Equals() = true for different transactions")
+	}
+}
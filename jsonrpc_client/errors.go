@@ -0,0 +1,218 @@
+package jsonrpc_client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var (
+	// ErrIDMismatch is returned when a response ID doesn't match its request
+	ErrIDMismatch = errors.New("response ID does not match request ID")
+	// ErrInvalidVersion is returned when a response isn't JSON-RPC 2.0
+	ErrInvalidVersion = errors.New("response is not JSON-RPC 2.0")
+	// ErrClientClosed is returned once a WebSocketClient has been closed
+	ErrClientClosed = errors.New("client closed")
+	// ErrNotConnected is returned while a WebSocketClient is reconnecting
+	ErrNotConnected = errors.New("websocket: not connected")
+	// ErrSubscriptionQueueOverflow ends a subscription whose consumer
+	// doesn't keep up with its notifications
+	ErrSubscriptionQueueOverflow = errors.New("subscription queue overflow")
+	// ErrRateLimitExceeded is returned by a fail-fast RateLimiter short of
+	// credits, without sending the request
+	ErrRateLimitExceeded = errors.New("client rate limit exceeded")
+	// ErrResponseTooLarge is returned for responses exceeding the maximum
+	// size set with WithMaxResponseSize
+	ErrResponseTooLarge = errors.New("response too large")
+	// ErrMissingField is reported in strict decoding mode for fields nodes
+	// always return that are absent from a response
+	ErrMissingField = errors.New("missing field")
+	// ErrNullField is reported in strict decoding mode for null fields that
+	// nodes never leave null
+	ErrNullField = errors.New("unexpected null")
+)
+
+// RPCError is a JSON-RPC error object returned by a node
+type RPCError struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+// Error implements the error interface
+func (rpcErr *RPCError) Error() string {
+	return fmt.Sprintf("json-rpc error %d: %s", rpcErr.Code, rpcErr.Message)
+}
+
+// asRPCError extracts an RPCError from err, if there is one
+func asRPCError(err error) (*RPCError, bool) {
+	var rpcErr *RPCError
+	if !errors.As(err, &rpcErr) {
+		return nil, false
+	}
+	return rpcErr, true
+}
+
+// IsMethodNotFound reports whether err is a "method not found" RPCError
+func IsMethodNotFound(err error) bool {
+	rpcErr, ok := asRPCError(err)
+	return ok && rpcErr.Code == METHOD_NOT_FOUND_CODE
+}
+
+// IsInvalidParams reports whether err is an "invalid params" RPCError
+func IsInvalidParams(err error) bool {
+	rpcErr, ok := asRPCError(err)
+	return ok && rpcErr.Code == INVALID_PARAMS_CODE
+}
+
+// IsRateLimited reports whether err is a 429 TransportError or an RPCError
+// signalling that the request rate or quota was exceeded
+func IsRateLimited(err error) bool {
+	if transportErr, ok := asTransportError(err); ok && transportErr.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	rpcErr, ok := asRPCError(err)
+	if !ok {
+		return false
+	}
+	if rpcErr.Code == LIMIT_EXCEEDED_CODE {
+		return true
+	}
+	message := strings.ToLower(rpcErr.Message)
+	return strings.Contains(message, "rate limit") ||
+		strings.Contains(message, "limit exceeded")
+}
+
+// IsExecutionReverted reports whether err is an RPCError caused by the EVM
+// reverting. The revert data, if any, is in the RPCError's Data.
+func IsExecutionReverted(err error) bool {
+	rpcErr, ok := asRPCError(err)
+	if !ok {
+		return false
+	}
+	return rpcErr.Code == EXECUTION_REVERTED_CODE ||
+		strings.HasPrefix(rpcErr.Message, "execution reverted")
+}
+
+// IsHeaderNotFound reports whether err is an RPCError caused by the node not
+// knowing the requested block, e.g. because it is pruned or not yet synced
+func IsHeaderNotFound(err error) bool {
+	rpcErr, ok := asRPCError(err)
+	return ok && rpcErr.Code == SERVER_ERROR_CODE &&
+		strings.Contains(rpcErr.Message, "header not found")
+}
+
+// VerificationError is returned when a block field doesn't match the value
+// computed from the rest of the data, meaning the node returned inconsistent
+// or forged data
+type VerificationError struct {
+	Field string
+	Got   string // as returned by the node
+	Want  string // as computed
+}
+
+// Error implements the error interface
+func (verErr *VerificationError) Error() string {
+	return fmt.Sprintf("verification failed: %s is %s, expected %s", verErr.Field, verErr.Got, verErr.Want)
+}
+
+// VerificationErrors lists every field failing verification, e.g. all the
+// sender fields of a transaction
+type VerificationErrors struct {
+	Fields []*VerificationError
+}
+
+// Error implements the error interface
+func (verErrs *VerificationErrors) Error() string {
+	messages := make([]string, len(verErrs.Fields))
+	for i, verErr := range verErrs.Fields {
+		messages[i] = verErr.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap returns the VerificationError of every field
+func (verErrs *VerificationErrors) Unwrap() []error {
+	errs := make([]error, len(verErrs.Fields))
+	for i, verErr := range verErrs.Fields {
+		errs[i] = verErr
+	}
+	return errs
+}
+
+// TransportError is returned for HTTP responses that don't carry a JSON-RPC
+// response: statuses other than 2xx, and bodies that aren't JSON, e.g. the
+// HTML error page of a proxy
+type TransportError struct {
+	StatusCode int
+	Header     http.Header
+	Body       string // the first TRANSPORT_ERROR_BODY_SNIPPET bytes
+
+	// rpcErr is the JSON-RPC error carried by the body, if any, e.g. along
+	// with a 429 status
+	rpcErr *RPCError
+}
+
+// newTransportError returns the TransportError of a response
+func newTransportError(resp *http.Response, body []byte) *TransportError {
+	transportErr := &TransportError{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+	}
+	var respBase ResponseBase
+	if json.Unmarshal(body, &respBase) == nil {
+		transportErr.rpcErr = respBase.Error
+	}
+	if len(body) > TRANSPORT_ERROR_BODY_SNIPPET {
+		body = body[:TRANSPORT_ERROR_BODY_SNIPPET]
+	}
+	transportErr.Body = string(body)
+	return transportErr
+}
+
+// Error implements the error interface
+func (transportErr *TransportError) Error() string {
+	message := fmt.Sprintf("HTTP status %d %s", transportErr.StatusCode, http.StatusText(transportErr.StatusCode))
+	if transportErr.rpcErr != nil {
+		return fmt.Sprintf("%s: %v", message, transportErr.rpcErr)
+	}
+	if transportErr.Body == "" {
+		return message + ": empty body"
+	}
+	return fmt.Sprintf("%s: %q", message, transportErr.Body)
+}
+
+// Unwrap returns the JSON-RPC error carried by the body, if any
+func (transportErr *TransportError) Unwrap() error {
+	if transportErr.rpcErr == nil {
+		return nil
+	}
+	return transportErr.rpcErr
+}
+
+// asTransportError extracts a TransportError from err, if there is one
+func asTransportError(err error) (*TransportError, bool) {
+	var transportErr *TransportError
+	if !errors.As(err, &transportErr) {
+		return nil, false
+	}
+	return transportErr, true
+}
+
+// IsUnauthorized reports whether err is a TransportError caused by missing or
+// invalid credentials, e.g. a wrong Infura project ID or secret
+func IsUnauthorized(err error) bool {
+	transportErr, ok := asTransportError(err)
+	return ok && (transportErr.StatusCode == http.StatusUnauthorized ||
+		transportErr.StatusCode == http.StatusForbidden)
+}
+
+// IsPayloadTooLarge reports whether err is a TransportError caused by the
+// request exceeding the size accepted by the endpoint, e.g. a batch with too
+// many requests
+func IsPayloadTooLarge(err error) bool {
+	transportErr, ok := asTransportError(err)
+	return ok && transportErr.StatusCode == http.StatusRequestEntityTooLarge
+}
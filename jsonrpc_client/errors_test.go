@@ -0,0 +1,153 @@
+package jsonrpc_client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRPCErrorClassification(t *testing.T) {
+
+	classifiers := map[string]func(error) bool{
+		"IsMethodNotFound":    IsMethodNotFound,
+		"IsInvalidParams":     IsInvalidParams,
+		"IsRateLimited":       IsRateLimited,
+		"IsExecutionReverted": IsExecutionReverted,
+		"IsHeaderNotFound":    IsHeaderNotFound,
+	}
+	tests := []struct {
+		err  *RPCError
+		want string
+	}{
+		{&RPCError{Code: METHOD_NOT_FOUND_CODE, Message: "the method foo does not exist"}, "IsMethodNotFound"},
+		{&RPCError{Code: INVALID_PARAMS_CODE, Message: "invalid argument 0"}, "IsInvalidParams"},
+		{&RPCError{Code: LIMIT_EXCEEDED_CODE, Message: "daily request count exceeded"}, "IsRateLimited"},
+		{&RPCError{Code: SERVER_ERROR_CODE, Message: "rate limit reached"}, "IsRateLimited"},
+		{&RPCError{Code: EXECUTION_REVERTED_CODE, Message: "execution reverted: paused"}, "IsExecutionReverted"},
+		{&RPCError{Code: SERVER_ERROR_CODE, Message: "execution reverted"}, "IsExecutionReverted"},
+		{&RPCError{Code: SERVER_ERROR_CODE, Message: "header not found"}, "IsHeaderNotFound"},
+		{&RPCError{Code: INTERNAL_ERROR_CODE, Message: "internal error"}, ""},
+	}
+	for _, test := range tests {
+		// the classifiers see through wrapping
+		err := fmt.Errorf("call: %w", test.err)
+		for name, classify := range classifiers {
+			if got := classify(err); got != (name == test.want) {
+				t.Errorf("%s(%v) = %t", name, test.err, got)
+			}
+		}
+	}
+
+	if IsMethodNotFound(errors.New("method not found")) {
+		t.Errorf("IsMethodNotFound() matched an error that isn't an RPCError")
+	}
+}
+
+func TestClientRPCError(t *testing.T) {
+
+	revert := &RPCError{Code: EXECUTION_REVERTED_CODE, Message: "execution reverted", Data: json.RawMessage(`"0x08c379a0"`)}
+	server := newRPCServer(t, func(method string, params []json.RawMessage) (interface{}, error) {
+		switch method {
+		case "eth_getBlockByHash":
+			return nil, &RPCError{Code: SERVER_ERROR_CODE, Message: "header not found"}
+		case "eth_call":
+			return nil, revert
+		}
+		return nil, &RPCError{Code: METHOD_NOT_FOUND_CODE, Message: "the method " + method + " does not exist"}
+	})
+	client := NewEthereumClient(server.URL)
+
+	// the error is returned instead of a zero value
+	block, err := client.Eth_getBlockByHash(Hash{1}, false)
+	if block != nil || !IsHeaderNotFound(err) {
+		t.Errorf("Eth_getBlockByHash() = %v, %v, want a header not found error", block, err)
+	}
+
+	_, err = client.Web3_clientVersion()
+	var rpcErr *RPCError
+	if !errors.As(err, &rpcErr) || rpcErr.Code != METHOD_NOT_FOUND_CODE || rpcErr.Message != "the method web3_clientVersion does not exist" {
+		t.Errorf("Web3_clientVersion() = %v, want a method not found RPCError", err)
+	}
+
+	var result string
+	err = client.Call(context.Background(), "eth_call", &result, map[string]string{}, "latest")
+	if !errors.As(err, &rpcErr) || string(rpcErr.Data) != `"0x08c379a0"` || !IsExecutionReverted(err) {
+		t.Errorf("Call(eth_call) = %v, want the revert with its data", err)
+	}
+}
+
+func TestClientTransportError(t *testing.T) {
+
+	longPage := "<html>" + strings.Repeat("x", 2*TRANSPORT_ERROR_BODY_SNIPPET) + "</html>"
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		wantBody string
+		classify func(error) bool
+		wantRPC  bool
+	}{
+		{"html 503", http.StatusServiceUnavailable, "<html>Service Unavailable</html>", "<html>Service Unavailable</html>", nil, false},
+		{"empty 401", http.StatusUnauthorized, "", "", IsUnauthorized, false},
+		{"403", http.StatusForbidden, "invalid project id", "invalid project id", IsUnauthorized, false},
+		{"413", http.StatusRequestEntityTooLarge, "", "", IsPayloadTooLarge, false},
+		{"429 with error", http.StatusTooManyRequests, `{"jsonrpc":"2.0","id":1,"error":{"code":-32005,"message":"project ID request rate exceeded"}}`, "", IsRateLimited, true},
+		{"html 200", http.StatusOK, longPage, longPage[:TRANSPORT_ERROR_BODY_SNIPPET], nil, false},
+	}
+	for _, test := range tests {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Test", test.name)
+			w.WriteHeader(test.status)
+			w.Write([]byte(test.body))
+		}))
+		_, err := NewEthereumClient(server.URL).Eth_blockNumber()
+		server.Close()
+
+		var transportErr *TransportError
+		if !errors.As(err, &transportErr) {
+			t.Errorf("%s: Eth_blockNumber() = %v, want a TransportError", test.name, err)
+			continue
+		}
+		if transportErr.StatusCode != test.status || transportErr.Header.Get("X-Test") != test.name {
+			t.Errorf("%s: StatusCode = %d, Header = %v", test.name, transportErr.StatusCode, transportErr.Header)
+		}
+		if test.wantBody != "" && transportErr.Body != test.wantBody {
+			t.Errorf("%s: Body = %q, want %q", test.name, transportErr.Body, test.wantBody)
+		}
+		if test.classify != nil && !test.classify(err) {
+			t.Errorf("%s: %v not classified", test.name, err)
+		}
+		var rpcErr *RPCError
+		if errors.As(err, &rpcErr) != test.wantRPC {
+			t.Errorf("%s: carried RPCError = %v", test.name, rpcErr)
+		}
+		if strings.Contains(err.Error(), "invalid character") {
+			t.Errorf("%s: Error() = %q, want the HTTP status", test.name, err)
+		}
+	}
+}
+
+func TestClientMaxResponseSize(t *testing.T) {
+
+	server := newRPCServer(t, func(method string, params []json.RawMessage) (interface{}, error) {
+		return strings.Repeat("ab", 100), nil
+	})
+
+	client := NewEthereumClient(server.URL, WithMaxResponseSize(100))
+	var result string
+	err := client.Call(context.Background(), "web3_clientVersion", &result)
+	if !errors.Is(err, ErrResponseTooLarge) {
+		t.Errorf("Call() = %v, want ErrResponseTooLarge", err)
+	}
+
+	client = NewEthereumClient(server.URL, WithMaxResponseSize(1000))
+	err = client.Call(context.Background(), "web3_clientVersion", &result)
+	if err != nil || len(result) != 200 {
+		t.Errorf("Call() = %d bytes, %v", len(result), err)
+	}
+}
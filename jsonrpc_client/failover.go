@@ -0,0 +1,310 @@
+package jsonrpc_client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// FailoverClient is an EthereumClient spreading calls over a prioritized list
+// of HTTP endpoints. It supports every EthereumClient method, sending each
+// call to the healthiest endpoint and failing over to the next one on
+// network errors, timeouts, 5xx statuses and rate limiting. Other failures,
+// e.g. 401 or 404 statuses, are returned without trying the other endpoints.
+// WithTimeout bounds each endpoint tried rather than the whole call.
+//
+// Code taking an *EthereumClient can be given the embedded EthereumClient,
+// which sends its calls through the failover too.
+type FailoverClient struct {
+	*EthereumClient
+
+	failover *failoverTransport
+}
+
+// EndpointStatus is the health of an endpoint of a FailoverClient
+type EndpointStatus struct {
+	URL         string
+	Healthy     bool   // false after a failed call or health check, until the next successful health check
+	Syncing     bool   // as reported by eth_syncing
+	BlockNumber uint64 // as reported by eth_blockNumber
+	Failures    int    // consecutive failed calls and health checks
+	LastError   error
+	LastCheck   time.Time // zero until the first health check completes
+}
+
+// NewFailoverClient creates a FailoverClient for the given endpoint URLs, in
+// order of preference. The options apply to every endpoint. Endpoints are
+// health-checked with eth_blockNumber and eth_syncing every
+// FAILOVER_HEALTH_CHECK_INTERVAL until the client is closed. Health checks
+// are neither rate limited nor retried.
+func NewFailoverClient(urls []string, opts ...ClientOption) (*FailoverClient, error) {
+
+	if len(urls) == 0 {
+		return nil, errors.New("NewFailoverClient: no endpoints")
+	}
+
+	failover := newFailoverTransport(urls, opts)
+	go failover.run(FAILOVER_HEALTH_CHECK_INTERVAL)
+
+	client := NewEthereumClient(urls[0], opts...)
+	client.transport = failover
+	// the endpoints have their own timeout
+	client.timeout = 0
+	return &FailoverClient{EthereumClient: client, failover: failover}, nil
+}
+
+// Status returns the health of every endpoint, in order of preference
+func (client *FailoverClient) Status() []EndpointStatus {
+	return client.failover.status()
+}
+
+// CheckHealth health-checks every endpoint now, without waiting for the next
+// periodic check
+func (client *FailoverClient) CheckHealth(ctx context.Context) {
+	client.failover.checkHealth(ctx)
+}
+
+// Close stops the health checks
+func (client *FailoverClient) Close() error {
+	client.failover.close()
+	return nil
+}
+
+// failoverEndpoint is an endpoint of a failoverTransport
+type failoverEndpoint struct {
+	index  int
+	client *EthereumClient
+	status EndpointStatus // guarded by failoverTransport.mu
+}
+
+// failoverTransport sends JSON-RPC payloads to the healthiest of its
+// endpoints, trying the others in turn when it fails
+type failoverTransport struct {
+	endpoints    []*failoverEndpoint
+	mu           sync.Mutex // guards the endpoint statuses
+	checkTimeout time.Duration
+
+	closing   chan struct{}
+	closeOnce sync.Once
+}
+
+// newFailoverTransport creates a failoverTransport for the given endpoint
+// URLs. The endpoint clients neither rate limit nor retry: the FailoverClient
+// does for calls, and health checks shouldn't spend the caller's budget.
+func newFailoverTransport(urls []string, opts []ClientOption) *failoverTransport {
+
+	failover := &failoverTransport{
+		endpoints:    make([]*failoverEndpoint, len(urls)),
+		checkTimeout: FAILOVER_HEALTH_CHECK_TIMEOUT,
+		closing:      make(chan struct{}),
+	}
+	for i, url := range urls {
+		client := NewEthereumClient(url, opts...)
+		client.limiter = nil
+		client.retry = RetryPolicy{}
+		failover.endpoints[i] = &failoverEndpoint{
+			index:  i,
+			client: client,
+			status: EndpointStatus{URL: url, Healthy: true},
+		}
+	}
+	return failover
+}
+
+// roundTrip implements transport
+func (t *failoverTransport) roundTrip(ctx context.Context, payload []byte, ids []int64) ([]byte, error) {
+
+	var lastErr error
+	for _, endpoint := range t.ranked() {
+		body, err := endpoint.post(ctx, payload)
+		if err == nil {
+			return body, nil
+		}
+		// the caller gave up, the endpoint didn't fail. An endpoint timing
+		// out is a failure like any other.
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if !isEndpointFailure(err) {
+			return nil, err
+		}
+		t.markFailed(endpoint, err)
+		lastErr = err
+	}
+	return nil, fmt.Errorf("failover: all %d endpoints failed: %w", len(t.endpoints), lastErr)
+}
+
+// isEndpointFailure reports whether a call failing with err may succeed on
+// another endpoint: after network errors, rate limiting, 5xx statuses and
+// successful statuses without a JSON body. Other statuses, e.g. 401, 403 or
+// 404, and oversized responses come from the request or its credentials and
+// would fail the same on any endpoint.
+func isEndpointFailure(err error) bool {
+	if errors.Is(err, ErrResponseTooLarge) {
+		return false
+	}
+	transportErr, ok := asTransportError(err)
+	if !ok {
+		return true
+	}
+	status := transportErr.StatusCode
+	return status == http.StatusTooManyRequests || status >= 500 || status < 400
+}
+
+// post sends payload to the endpoint, and fails on transport errors, timeouts
+// and rate limiting
+func (endpoint *failoverEndpoint) post(ctx context.Context, payload []byte) ([]byte, error) {
+
+	timeout := endpoint.client.timeout
+	if timeout <= 0 {
+		timeout = FAILOVER_CALL_TIMEOUT
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	body, err := endpoint.client.post(ctx, payload)
+	if err == nil {
+		err = rateLimitError(body)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("endpoint %d: %w", endpoint.index, err)
+	}
+	return body, nil
+}
+
+// ranked returns the endpoints in the order they should be tried: healthy
+// endpoints within FAILOVER_MAX_BLOCK_LAG of the highest block first, then
+// lagging ones, then unhealthy ones, each in order of preference
+func (t *failoverTransport) ranked() []*failoverEndpoint {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var highest uint64
+	for _, endpoint := range t.endpoints {
+		if endpoint.status.Healthy && endpoint.status.BlockNumber > highest {
+			highest = endpoint.status.BlockNumber
+		}
+	}
+
+	rank := make(map[*failoverEndpoint]int, len(t.endpoints))
+	for _, endpoint := range t.endpoints {
+		switch {
+		case !endpoint.status.Healthy:
+			rank[endpoint] = 2
+		case endpoint.status.BlockNumber+FAILOVER_MAX_BLOCK_LAG < highest:
+			rank[endpoint] = 1
+		default:
+			rank[endpoint] = 0
+		}
+	}
+
+	ranked := make([]*failoverEndpoint, len(t.endpoints))
+	copy(ranked, t.endpoints)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return rank[ranked[i]] < rank[ranked[j]]
+	})
+	return ranked
+}
+
+// markFailed records a failed call to the endpoint
+func (t *failoverTransport) markFailed(endpoint *failoverEndpoint, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	endpoint.status.Healthy = false
+	endpoint.status.Failures++
+	endpoint.status.LastError = err
+}
+
+// status returns a copy of the endpoint statuses
+func (t *failoverTransport) status() []EndpointStatus {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	statuses := make([]EndpointStatus, len(t.endpoints))
+	for i, endpoint := range t.endpoints {
+		statuses[i] = endpoint.status
+	}
+	return statuses
+}
+
+// run health-checks the endpoints immediately and then every interval, until
+// the transport is closed
+func (t *failoverTransport) run(interval time.Duration) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-t.closing
+		cancel()
+	}()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		t.checkHealth(ctx)
+		select {
+		case <-ticker.C:
+		case <-t.closing:
+			return
+		}
+	}
+}
+
+// checkHealth health-checks every endpoint concurrently
+func (t *failoverTransport) checkHealth(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, endpoint := range t.endpoints {
+		wg.Add(1)
+		go func(endpoint *failoverEndpoint) {
+			defer wg.Done()
+			t.checkEndpoint(ctx, endpoint)
+		}(endpoint)
+	}
+	wg.Wait()
+}
+
+// checkEndpoint health-checks an endpoint: it is healthy when it answers
+// eth_blockNumber and eth_syncing within the check timeout and isn't syncing
+func (t *failoverTransport) checkEndpoint(ctx context.Context, endpoint *failoverEndpoint) {
+
+	checkCtx, cancel := context.WithTimeout(ctx, t.checkTimeout)
+	defer cancel()
+
+	blockNumber, err := endpoint.client.Eth_blockNumberContext(checkCtx)
+	var syncing bool
+	if err == nil {
+		syncing, err = endpoint.client.Eth_syncingContext(checkCtx)
+	}
+	// the client was closed during the check
+	if ctx.Err() != nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	endpoint.status.LastCheck = time.Now()
+	if err != nil {
+		endpoint.status.Healthy = false
+		endpoint.status.Failures++
+		endpoint.status.LastError = fmt.Errorf("endpoint %d: %w", endpoint.index, err)
+		return
+	}
+	endpoint.status.Healthy = !syncing
+	endpoint.status.Syncing = syncing
+	endpoint.status.BlockNumber = blockNumber
+	endpoint.status.Failures = 0
+	endpoint.status.LastError = nil
+}
+
+// close stops the health checks
+func (t *failoverTransport) close() {
+	t.closeOnce.Do(func() {
+		close(t.closing)
+	})
+}
@@ -0,0 +1,251 @@
+package jsonrpc_client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testEndpoint is a failover endpoint test server answering eth_chainId with
+// its id, or failing it with a given HTTP status
+type testEndpoint struct {
+	id          uint64
+	blockNumber uint64
+	syncing     bool
+	status      int32 // accessed atomically, 0 to answer
+	calls       int32 // eth_chainId calls, accessed atomically
+}
+
+// handle implements rpcHandler
+func (endpoint *testEndpoint) handle(method string, params []json.RawMessage) (interface{}, error) {
+	switch method {
+	case "eth_blockNumber":
+		return hexResult(endpoint.blockNumber), nil
+	case "eth_syncing":
+		if endpoint.syncing {
+			return map[string]string{"startingBlock": "0x0", "currentBlock": "0x1", "highestBlock": "0x10"}, nil
+		}
+		return false, nil
+	case "eth_chainId":
+		atomic.AddInt32(&endpoint.calls, 1)
+		if status := atomic.LoadInt32(&endpoint.status); status != 0 {
+			return nil, statusError(status)
+		}
+		return hexResult(endpoint.id), nil
+	}
+	return nil, &RPCError{Code: METHOD_NOT_FOUND_CODE, Message: "method not found"}
+}
+
+// newTestFailoverClient starts the endpoints and returns a FailoverClient
+// for them, health-checked once
+func newTestFailoverClient(t *testing.T, endpoints []*testEndpoint, opts ...ClientOption) *FailoverClient {
+	urls := make([]string, len(endpoints))
+	for i, endpoint := range endpoints {
+		urls[i] = newRPCServer(t, endpoint.handle).URL
+	}
+	client, err := NewFailoverClient(urls, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Close() })
+	client.CheckHealth(context.Background())
+	return client
+}
+
+// chainId returns the id of the endpoint answering eth_chainId
+func chainId(client *FailoverClient) (uint64, error) {
+	var id HexUint64
+	err := client.Call(context.Background(), "eth_chainId", &id)
+	return uint64(id), err
+}
+
+func TestFailoverRanking(t *testing.T) {
+
+	endpoints := []*testEndpoint{
+		{id: 0, blockNumber: 200, syncing: true},
+		{id: 1, blockNumber: 100},
+		{id: 2, blockNumber: 200},
+	}
+	client := newTestFailoverClient(t, endpoints)
+
+	status := client.Status()
+	if status[0].Healthy || !status[0].Syncing || !status[1].Healthy || !status[2].Healthy {
+		t.Fatalf("Status() = %+v", status)
+	}
+	if status[2].BlockNumber != 200 || status[2].LastCheck.IsZero() {
+		t.Errorf("Status()[2] = %+v", status[2])
+	}
+
+	// the synced endpoint first, then the lagging one, then the syncing one
+	for _, want := range []uint64{2, 1, 0} {
+		id, err := chainId(client)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id != want {
+			t.Errorf("answered by endpoint %d, want %d", id, want)
+		}
+		atomic.StoreInt32(&endpoints[want].status, http.StatusServiceUnavailable)
+	}
+
+	_, err := chainId(client)
+	if err == nil {
+		t.Fatal("no error with every endpoint failing")
+	}
+	var transportErr *TransportError
+	if !errors.As(err, &transportErr) || transportErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("error = %v, want the last 503", err)
+	}
+}
+
+func TestFailoverStatuses(t *testing.T) {
+
+	tests := []struct {
+		status   int
+		failover bool
+	}{
+		{http.StatusTooManyRequests, true},
+		{http.StatusInternalServerError, true},
+		{http.StatusBadGateway, true},
+		{http.StatusServiceUnavailable, true},
+		{http.StatusGatewayTimeout, true},
+		{http.StatusBadRequest, false},
+		{http.StatusUnauthorized, false},
+		{http.StatusForbidden, false},
+		{http.StatusNotFound, false},
+		{http.StatusRequestEntityTooLarge, false},
+	}
+	for _, test := range tests {
+		endpoints := []*testEndpoint{{id: 0, blockNumber: 1, status: int32(test.status)}, {id: 1, blockNumber: 1}}
+		client := newTestFailoverClient(t, endpoints)
+
+		id, err := chainId(client)
+		if test.failover {
+			if err != nil || id != 1 {
+				t.Errorf("%d: got endpoint %d and error %v, want endpoint 1", test.status, id, err)
+			}
+			if calls := atomic.LoadInt32(&endpoints[0].calls); calls != 1 {
+				t.Errorf("%d: endpoint 0 called %d times, want 1", test.status, calls)
+			}
+			continue
+		}
+		var transportErr *TransportError
+		if !errors.As(err, &transportErr) || transportErr.StatusCode != test.status {
+			t.Errorf("%d: error = %v, want the endpoint status", test.status, err)
+		}
+		if calls := atomic.LoadInt32(&endpoints[1].calls); calls != 0 {
+			t.Errorf("%d: failed over to endpoint 1", test.status)
+		}
+		if !client.Status()[0].Healthy {
+			t.Errorf("%d: endpoint 0 marked unhealthy", test.status)
+		}
+	}
+}
+
+func TestFailoverHealthCheckTimeout(t *testing.T) {
+
+	release := make(chan struct{})
+	hung := newRPCServer(t, func(method string, params []json.RawMessage) (interface{}, error) {
+		<-release
+		return nil, errors.New("released")
+	})
+	t.Cleanup(func() { close(release) })
+	healthy := newRPCServer(t, (&testEndpoint{blockNumber: 7}).handle)
+
+	failover := newFailoverTransport([]string{hung.URL, healthy.URL}, nil)
+	failover.checkTimeout = 50 * time.Millisecond
+
+	start := time.Now()
+	failover.checkHealth(context.Background())
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("health check took %v", elapsed)
+	}
+
+	status := failover.status()
+	if status[0].Healthy || !errors.Is(status[0].LastError, context.DeadlineExceeded) {
+		t.Errorf("hung endpoint status = %+v", status[0])
+	}
+	if !status[1].Healthy || status[1].BlockNumber != 7 {
+		t.Errorf("healthy endpoint status = %+v", status[1])
+	}
+}
+
+func TestFailoverCallTimeout(t *testing.T) {
+
+	// the first endpoint passes its health checks but hangs on calls
+	release := make(chan struct{})
+	hung := &testEndpoint{id: 0, blockNumber: 1}
+	hungServer := newRPCServer(t, func(method string, params []json.RawMessage) (interface{}, error) {
+		if method == "eth_chainId" {
+			<-release
+		}
+		return hung.handle(method, params)
+	})
+	t.Cleanup(func() { close(release) })
+	healthy := newRPCServer(t, (&testEndpoint{id: 1, blockNumber: 1}).handle)
+	urls := []string{hungServer.URL, healthy.URL}
+
+	client, err := NewFailoverClient(urls, WithTimeout(100*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	client.CheckHealth(context.Background())
+
+	// callers of an *EthereumClient fail over too
+	var id HexUint64
+	err = client.EthereumClient.Call(context.Background(), "eth_chainId", &id)
+	if err != nil || id != 1 {
+		t.Errorf("got endpoint %d and error %v, want endpoint 1", id, err)
+	}
+	status := client.Status()[0]
+	if status.Healthy || status.Failures != 1 || !errors.Is(status.LastError, context.DeadlineExceeded) {
+		t.Errorf("hung endpoint status = %+v", status)
+	}
+
+	// the caller giving up isn't the endpoint's failure
+	client, err = NewFailoverClient(urls)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	client.CheckHealth(context.Background())
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err = client.Call(ctx, "eth_chainId", &id)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error = %v, want context.DeadlineExceeded", err)
+	}
+	if status := client.Status()[0]; !status.Healthy || status.Failures != 0 {
+		t.Errorf("hung endpoint status = %+v after the caller gave up", status)
+	}
+}
+
+func TestFailoverHealthCheckRateLimit(t *testing.T) {
+
+	limiter := NewRateLimiter(RateLimit{PerSecond: 0.001, Burst: 1, FailFast: true})
+	endpoints := []*testEndpoint{{id: 0, blockNumber: 1}, {id: 1, blockNumber: 1}}
+	client := newTestFailoverClient(t, endpoints, WithRateLimiter(limiter))
+	client.CheckHealth(context.Background())
+
+	if usage := limiter.Usage(); usage.Requests != 0 || usage.Rejected != 0 {
+		t.Errorf("health checks spent credits: %+v", usage)
+	}
+	for _, status := range client.Status() {
+		if !status.Healthy {
+			t.Errorf("endpoint status = %+v", status)
+		}
+	}
+
+	// calls are rate limited once, whichever endpoint answers
+	if _, err := chainId(client); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := chainId(client); !errors.Is(err, ErrRateLimitExceeded) {
+		t.Errorf("error = %v, want ErrRateLimitExceeded", err)
+	}
+}
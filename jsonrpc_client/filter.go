@@ -0,0 +1,95 @@
+package jsonrpc_client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+// FilterQuery selects logs by block range or block hash, emitting address
+// and topics
+type FilterQuery struct {
+	FromBlock *BlockNumberOrTag // nil for the node's default, the latest block
+	ToBlock   *BlockNumberOrTag // nil for the node's default, the latest block
+	BlockHash *Hash             // excludes FromBlock and ToBlock (EIP-234)
+	Addresses []Address
+	// Topics are matched by position: each position lists alternatives that
+	// are OR-ed together, and an empty position matches any topic
+	Topics [][]Hash
+}
+
+// MarshalJSON encodes a FilterQuery as a JSON-RPC filter object
+func (q FilterQuery) MarshalJSON() ([]byte, error) {
+
+	filter := make(map[string]interface{})
+
+	if q.BlockHash != nil {
+		if q.FromBlock != nil || q.ToBlock != nil {
+			return nil, errors.New("FilterQuery: BlockHash excludes FromBlock and ToBlock")
+		}
+		filter["blockHash"] = *q.BlockHash
+	}
+	if q.FromBlock != nil {
+		if _, _, ok := q.FromBlock.Hash(); ok {
+			return nil, errors.New("FilterQuery: FromBlock must be a number or tag")
+		}
+		filter["fromBlock"] = q.FromBlock.String()
+	}
+	if q.ToBlock != nil {
+		if _, _, ok := q.ToBlock.Hash(); ok {
+			return nil, errors.New("FilterQuery: ToBlock must be a number or tag")
+		}
+		filter["toBlock"] = q.ToBlock.String()
+	}
+
+	if len(q.Addresses) == 1 {
+		filter["address"] = q.Addresses[0]
+	} else if len(q.Addresses) > 1 {
+		filter["address"] = q.Addresses
+	}
+
+	if len(q.Topics) > 0 {
+		topics := make([]interface{}, len(q.Topics))
+		for i, alternatives := range q.Topics {
+			switch len(alternatives) {
+			case 0:
+				topics[i] = nil // wildcard
+			case 1:
+				topics[i] = alternatives[0]
+			default:
+				topics[i] = alternatives
+			}
+		}
+		filter["topics"] = topics
+	}
+
+	return json.Marshal(filter)
+}
+
+// FilterChanges is the result of eth_getFilterChanges: block or transaction
+// hashes for block and pending transaction filters, logs for log filters
+type FilterChanges struct {
+	Hashes []Hash
+	Logs   []Log
+}
+
+// UnmarshalJSON decodes hashes or logs depending on the shape of the result
+func (changes *FilterChanges) UnmarshalJSON(b []byte) error {
+
+	var items []json.RawMessage
+	err := json.Unmarshal(b, &items)
+	if err != nil {
+		return err
+	}
+
+	*changes = FilterChanges{}
+	if len(items) == 0 {
+		return nil
+	}
+
+	if bytes.HasPrefix(bytes.TrimSpace(items[0]), []byte(`"`)) {
+		return json.Unmarshal(b, &changes.Hashes)
+	}
+
+	return json.Unmarshal(b, &changes.Logs)
+}
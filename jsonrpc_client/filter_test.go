@@ -0,0 +1,187 @@
+package jsonrpc_client
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestFilterQueryJSON(t *testing.T) {
+
+	from, to := BlockAtNumber(0x10), FinalizedBlock
+	hash := Hash{31: 0xab}
+	a, b := BytesToAddress([]byte{0xa}), BytesToAddress([]byte{0xb})
+	t1, t2, t3 := Hash{31: 1}, Hash{31: 2}, Hash{31: 3}
+
+	// addresses are sent in lower case
+	tests := []struct {
+		name  string
+		query FilterQuery
+		want  string
+	}{
+		{"empty", FilterQuery{}, `{}`},
+		{
+			"range and single address",
+			FilterQuery{FromBlock: &from, ToBlock: &to, Addresses: []Address{a}},
+			`{"address":"0x000000000000000000000000000000000000000a","fromBlock":"0x10","toBlock":"finalized"}`,
+		},
+		{
+			"block hash and addresses",
+			FilterQuery{BlockHash: &hash, Addresses: []Address{a, b}},
+			`{"address":["0x000000000000000000000000000000000000000a","0x000000000000000000000000000000000000000b"],"blockHash":"` + hash.String() + `"}`,
+		},
+		{
+			"topic alternatives and wildcard",
+			FilterQuery{Topics: [][]Hash{{t1}, nil, {t2, t3}}},
+			`{"topics":["` + t1.String() + `",null,["` + t2.String() + `","` + t3.String() + `"]]}`,
+		},
+	}
+	for _, test := range tests {
+		b, err := json.Marshal(test.query)
+		if err != nil {
+			t.Errorf("%s: Marshal(): %v", test.name, err)
+			continue
+		}
+		if string(b) != test.want {
+			t.Errorf("%s: Marshal() = %s, want %s", test.name, b, test.want)
+		}
+	}
+
+	byHash := BlockAtHash(hash, false)
+	invalid := []FilterQuery{
+		{BlockHash: &hash, FromBlock: &from},
+		{BlockHash: &hash, ToBlock: &to},
+		{FromBlock: &byHash},
+		{ToBlock: &byHash},
+	}
+	for i, query := range invalid {
+		_, err := json.Marshal(query)
+		if err == nil {
+			t.Errorf("%d: Marshal() accepted an invalid query", i)
+		}
+	}
+}
+
+func TestFilterChangesJSON(t *testing.T) {
+
+	var changes FilterChanges
+	err := json.Unmarshal([]byte(`["0x000000000000000000000000000000000000000000000000000000000000000a"]`), &changes)
+	if err != nil || len(changes.Hashes) != 1 || changes.Hashes[0] != (Hash{31: 0xa}) || changes.Logs != nil {
+		t.Errorf("Unmarshal(hashes) = %+v, %v", changes, err)
+	}
+
+	err = json.Unmarshal([]byte(`[{"address":"0x0000000000000000000000000000000000000002","topics":[],"data":"0x01","blockHash":null,"blockNumber":null,"transactionHash":null,"transactionIndex":null,"logIndex":null,"removed":false}]`), &changes)
+	if err != nil || len(changes.Logs) != 1 || changes.Hashes != nil || changes.Logs[0].BlockNumber != nil {
+		t.Errorf("Unmarshal(pending logs) = %+v, %v", changes, err)
+	}
+
+	err = json.Unmarshal([]byte(`[]`), &changes)
+	if err != nil || changes.Hashes != nil || changes.Logs != nil {
+		t.Errorf("Unmarshal(empty) = %+v, %v", changes, err)
+	}
+}
+
+func TestLogJSON(t *testing.T) {
+
+	log := mustDecodeReceipt(t, blobReceipt).Logs[0]
+	b, err := log.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON(): %v", err)
+	}
+	stored, err := NewLogFromJSON(b)
+	if err != nil || !log.Equals(stored) {
+		t.Errorf("stored round trip = %+v, %v", stored, err)
+	}
+
+	b, err = json.Marshal(log)
+	if err != nil {
+		t.Fatalf("Marshal(): %v", err)
+	}
+	var decoded Log
+	err = json.Unmarshal(b, &decoded)
+	if err != nil || !log.Equals(&decoded) {
+		t.Errorf("JSON-RPC round trip = %s, %v", b, err)
+	}
+}
+
+func TestClientLogFilters(t *testing.T) {
+
+	logs := `[` + mustMarshalLog(t, blobReceipt) + `]`
+	var newFilterParams json.RawMessage
+	server := newRPCServer(t, func(method string, params []json.RawMessage) (interface{}, error) {
+		switch method {
+		case "eth_newFilter":
+			newFilterParams = params[0]
+			return "0x1", nil
+		case "eth_newBlockFilter":
+			return "0x2", nil
+		case "eth_getLogs", "eth_getFilterLogs":
+			return json.RawMessage(logs), nil
+		case "eth_getFilterChanges":
+			if string(params[0]) == `"0x1"` {
+				return json.RawMessage(logs), nil
+			}
+			return []Hash{{31: 0xab}}, nil
+		case "eth_uninstallFilter":
+			return string(params[0]) == `"0x1"`, nil
+		}
+		return nil, &RPCError{Code: METHOD_NOT_FOUND_CODE, Message: "method not found"}
+	})
+	client := NewEthereumClient(server.URL)
+
+	address := BytesToAddress([]byte{2})
+	query := FilterQuery{Addresses: []Address{address}, Topics: [][]Hash{{{31: 0xa}}}}
+	id, err := client.Eth_newFilter(query)
+	if err != nil || id != "0x1" {
+		t.Fatalf("Eth_newFilter() = %q, %v", id, err)
+	}
+	want, _ := json.Marshal(query)
+	if string(newFilterParams) != string(want) {
+		t.Errorf("eth_newFilter sent %s, want %s", newFilterParams, want)
+	}
+
+	got, err := client.Eth_getLogs(query)
+	if err != nil || len(got) != 1 || got[0].Address != address {
+		t.Errorf("Eth_getLogs() = %+v, %v", got, err)
+	}
+	got, err = client.Eth_getFilterLogs(id)
+	if err != nil || len(got) != 1 {
+		t.Errorf("Eth_getFilterLogs() = %+v, %v", got, err)
+	}
+
+	// the changes of a log filter are logs and those of a block filter hashes
+	changes, err := client.GetFilterChanges(id)
+	if err != nil || len(changes.Logs) != 1 || changes.Hashes != nil {
+		t.Errorf("GetFilterChanges(log filter) = %+v, %v", changes, err)
+	}
+	blockID, err := client.Eth_newBlockFilter()
+	if err != nil {
+		t.Fatalf("Eth_newBlockFilter(): %v", err)
+	}
+	changes, err = client.GetFilterChanges(blockID)
+	if err != nil || len(changes.Hashes) != 1 || changes.Logs != nil {
+		t.Errorf("GetFilterChanges(block filter) = %+v, %v", changes, err)
+	}
+	hashes, err := client.Eth_getFilterChanges(blockID)
+	if err != nil || len(hashes) != 1 || hashes[0] != (Hash{31: 0xab}) {
+		t.Errorf("Eth_getFilterChanges() = %v, %v", hashes, err)
+	}
+
+	removed, err := client.Eth_uninstallFilter(id)
+	if err != nil || !removed {
+		t.Errorf("Eth_uninstallFilter() = %t, %v", removed, err)
+	}
+	removed, err = client.Eth_uninstallFilter("0x9")
+	if err != nil || removed {
+		t.Errorf("Eth_uninstallFilter(unknown) = %t, %v", removed, err)
+	}
+}
+
+// mustMarshalLog returns the JSON-RPC form of the first log of a receipt
+func mustMarshalLog(t *testing.T, receipt string) string {
+	t.Helper()
+	b, err := json.Marshal(mustDecodeReceipt(t, receipt).Logs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
@@ -0,0 +1,67 @@
+package jsonrpc_client
+
+import (
+	"bytes"
+	"encoding/hex"
+)
+
+// HASH_LENGTH is the length of a keccak256 hash in bytes
+const HASH_LENGTH = 32
+
+// Hash is a 32 byte keccak256 hash, such as a block or transaction hash, a
+// trie root or a log topic. It is encoded as a 0x-prefixed hex string and
+// can be compared with == and used as a map key.
+type Hash [HASH_LENGTH]byte
+
+// ParseHash parses a 0x-prefixed hex string of exactly 32 bytes
+func ParseHash(s string) (Hash, error) {
+	var hash Hash
+	err := decodeFixedHex(hash[:], s, "hash")
+	return hash, err
+}
+
+// BytesToHash returns the Hash of b, keeping its last 32 bytes if it is
+// longer and left padding it with zeros if it is shorter
+func BytesToHash(b []byte) Hash {
+	var hash Hash
+	if len(b) > HASH_LENGTH {
+		b = b[len(b)-HASH_LENGTH:]
+	}
+	copy(hash[HASH_LENGTH-len(b):], b)
+	return hash
+}
+
+// Bytes returns the bytes of the hash
+func (hash Hash) Bytes() []byte {
+	return hash[:]
+}
+
+// String returns the lowercase 0x-prefixed hex encoding
+func (hash Hash) String() string {
+	return "0x" + hex.EncodeToString(hash[:])
+}
+
+// IsZero reports whether all bytes of the hash are zero
+func (hash Hash) IsZero() bool {
+	return hash == Hash{}
+}
+
+// Cmp compares two hashes as big-endian numbers, returning -1, 0 or +1
+func (hash Hash) Cmp(hash2 Hash) int {
+	return bytes.Compare(hash[:], hash2[:])
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (hash Hash) MarshalText() ([]byte, error) {
+	return []byte(hash.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (hash *Hash) UnmarshalText(text []byte) error {
+	parsed, err := ParseHash(string(text))
+	if err != nil {
+		return err
+	}
+	*hash = parsed
+	return nil
+}
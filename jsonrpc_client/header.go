@@ -0,0 +1,191 @@
+package jsonrpc_client
+
+import (
+	"fmt"
+
+	"github.com/INFURA/go-libs/crypto"
+	"github.com/INFURA/go-libs/rlp"
+)
+
+// HeaderRLP returns the RLP encoding of the block header, including the
+// fields added by the forks active at the block's number and timestamp
+// under config. With a nil config, the fork fields present are encoded.
+func (block *Block) HeaderRLP(config *ChainConfig) ([]byte, error) {
+
+	items := []interface{}{
+		block.ParentHash, block.SHA3Uncles, block.Miner, block.StateRoot,
+		block.TransactionsRoot, block.ReceiptsRoot, block.LogsBloom, block.Difficulty,
+		block.Number, block.GasLimit, block.GasUsed, block.Timestamp, block.ExtraData,
+	}
+
+	if block.MixHash.IsZero() && len(block.SealFields) > 0 {
+		// Parity reports the seal of other engines as already encoded items
+		for _, field := range block.SealFields {
+			items = append(items, rlp.RawValue(field))
+		}
+	} else {
+		var nonce [8]byte
+		if block.Nonce != nil {
+			if block.Nonce.Sign() < 0 || block.Nonce.BitLen() > 64 {
+				return nil, fmt.Errorf("HeaderRLP Nonce: invalid value %v", block.Nonce)
+			}
+			block.Nonce.FillBytes(nonce[:])
+		}
+		items = append(items, block.MixHash, nonce)
+	}
+
+	london, shanghai, cancun, prague := block.headerForks(config)
+	forkFields := []struct {
+		field   string
+		value   interface{}
+		present bool
+		active  bool
+		fork    string
+	}{
+		{"BaseFeePerGas", block.BaseFeePerGas, block.BaseFeePerGas != nil, london, "London"},
+		{"WithdrawalsRoot", block.WithdrawalsRoot, block.WithdrawalsRoot != nil, shanghai, "Shanghai"},
+		{"BlobGasUsed", block.BlobGasUsed, block.BlobGasUsed != nil, cancun, "Cancun"},
+		{"ExcessBlobGas", block.ExcessBlobGas, block.ExcessBlobGas != nil, cancun, "Cancun"},
+		{"ParentBeaconBlockRoot", block.ParentBeaconBlockRoot, block.ParentBeaconBlockRoot != nil, cancun, "Cancun"},
+		{"RequestsHash", block.RequestsHash, block.RequestsHash != nil, prague, "Prague"},
+	}
+	for _, f := range forkFields {
+		switch {
+		case f.present && !f.active:
+			return nil, fmt.Errorf("HeaderRLP %s: set before %s", f.field, f.fork)
+		case !f.present && f.active:
+			return nil, fmt.Errorf("HeaderRLP %s: missing after %s", f.field, f.fork)
+		case f.present:
+			items = append(items, f.value)
+		}
+	}
+
+	enc, err := rlp.EncodeToBytes(items)
+	if err != nil {
+		return nil, fmt.Errorf("HeaderRLP %v", err)
+	}
+	return enc, nil
+}
+
+// headerForks returns which of the forks extending the header are active for
+// the block
+func (block *Block) headerForks(config *ChainConfig) (london, shanghai, cancun, prague bool) {
+	if config != nil {
+		return config.IsLondon(block.Number), config.IsShanghai(block.Timestamp),
+			config.IsCancun(block.Timestamp), config.IsPrague(block.Timestamp)
+	}
+
+	// without a config, the latest fork whose fields are present is active
+	prague = block.RequestsHash != nil
+	cancun = prague || block.BlobGasUsed != nil || block.ExcessBlobGas != nil ||
+		block.ParentBeaconBlockRoot != nil
+	shanghai = cancun || block.WithdrawalsRoot != nil
+	london = shanghai || block.BaseFeePerGas != nil
+	return london, shanghai, cancun, prague
+}
+
+// ComputeHash returns the keccak256 hash of the block header
+func (block *Block) ComputeHash(config *ChainConfig) (Hash, error) {
+	enc, err := block.HeaderRLP(config)
+	if err != nil {
+		return Hash{}, err
+	}
+	return BytesToHash(crypto.Keccak256(enc)), nil
+}
+
+// VerifyHash checks that Hash is the hash of the block header
+func (block *Block) VerifyHash(config *ChainConfig) error {
+	hash, err := block.ComputeHash(config)
+	if err != nil {
+		return err
+	}
+	return verifyField("Hash", block.Hash, hash)
+}
+
+// VerifyParent checks that the block extends parent
+func (block *Block) VerifyParent(parent *Block) error {
+
+	err := verifyField("ParentHash", block.ParentHash, parent.Hash)
+	if err != nil {
+		return err
+	}
+	if block.Number != parent.Number+1 {
+		return &VerificationError{
+			Field: "Number",
+			Got:   fmt.Sprint(block.Number),
+			Want:  fmt.Sprint(parent.Number + 1),
+		}
+	}
+	if block.Timestamp <= parent.Timestamp {
+		return &VerificationError{
+			Field: "Timestamp",
+			Got:   fmt.Sprint(block.Timestamp),
+			Want:  fmt.Sprintf("more than %d", parent.Timestamp),
+		}
+	}
+	return nil
+}
+
+// VerifyTransactionsRoot checks that TransactionsRoot commits to the block's
+// transactions, which must have been fetched in full
+func (block *Block) VerifyTransactionsRoot() error {
+
+	items := make([][]byte, len(block.Transactions))
+	for i := range block.Transactions {
+		enc, err := block.Transactions[i].MarshalBinary()
+		if err != nil {
+			return fmt.Errorf("VerifyTransactionsRoot Transactions[%d]: %v", i, err)
+		}
+		items[i] = enc
+	}
+	return verifyField("TransactionsRoot", block.TransactionsRoot, BytesToHash(deriveRoot(items)))
+}
+
+// VerifyReceiptsRoot checks that ReceiptsRoot commits to the receipts of the
+// block's transactions, in order
+func (block *Block) VerifyReceiptsRoot(receipts []Receipt) error {
+
+	items := make([][]byte, len(receipts))
+	for i := range receipts {
+		enc, err := receipts[i].MarshalBinary()
+		if err != nil {
+			return fmt.Errorf("VerifyReceiptsRoot receipts[%d]: %v", i, err)
+		}
+		items[i] = enc
+	}
+	return verifyField("ReceiptsRoot", block.ReceiptsRoot, BytesToHash(deriveRoot(items)))
+}
+
+// VerifyWithdrawalsRoot checks that WithdrawalsRoot commits to the block's
+// withdrawals
+func (block *Block) VerifyWithdrawalsRoot() error {
+
+	if block.WithdrawalsRoot == nil {
+		return fmt.Errorf("VerifyWithdrawalsRoot: block %d has no withdrawals root", block.Number)
+	}
+
+	items := make([][]byte, len(block.Withdrawals))
+	for i := range block.Withdrawals {
+		enc, err := rlp.EncodeToBytes(&block.Withdrawals[i])
+		if err != nil {
+			return fmt.Errorf("VerifyWithdrawalsRoot Withdrawals[%d] %v", i, err)
+		}
+		items[i] = enc
+	}
+	return verifyField("WithdrawalsRoot", *block.WithdrawalsRoot, BytesToHash(deriveRoot(items)))
+}
+
+// verifyField returns a VerificationError unless the values are equal
+func verifyField(field string, got, want fmt.Stringer) error {
+	if got.String() != want.String() {
+		return &VerificationError{Field: field, Got: got.String(), Want: want.String()}
+	}
+	return nil
+}
+
+// verifyField records a VerificationError unless the values are equal
+func (verErrs *VerificationErrors) verifyField(field string, got, want fmt.Stringer) {
+	if got.String() != want.String() {
+		verErrs.Fields = append(verErrs.Fields, &VerificationError{Field: field, Got: got.String(), Want: want.String()})
+	}
+}
@@ -0,0 +1,337 @@
+package jsonrpc_client
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/INFURA/go-libs/crypto"
+	"github.com/INFURA/go-libs/rlp"
+)
+
+const (
+	emptyBloom = "0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"
+	emptyRoot  = "0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421"
+)
+
+// mainnetGenesis and mainnetBlock1 are the first two mainnet blocks
+const mainnetGenesis = `{
+	"difficulty": "0x400000000",
+	"extraData": "0x11bbe8db4e347b4e8c937c1c8370e4b5ed33adb3db69cbdb7a38e1e50b1b82fa",
+	"gasLimit": "0x1388",
+	"gasUsed": "0x0",
+	"hash": "0xd4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3",
+	"logsBloom": "` + emptyBloom + `",
+	"miner": "0x0000000000000000000000000000000000000000",
+	"mixHash": "0x0000000000000000000000000000000000000000000000000000000000000000",
+	"nonce": "0x0000000000000042",
+	"number": "0x0",
+	"parentHash": "0x0000000000000000000000000000000000000000000000000000000000000000",
+	"receiptsRoot": "` + emptyRoot + `",
+	"sha3Uncles": "0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347",
+	"stateRoot": "0xd7f8974fb5ac78d9ac099b9ad5018bedc2ce0a72dad1827a1709da30580f0544",
+	"size": "0x21c",
+	"timestamp": "0x0",
+	"totalDifficulty": "0x400000000",
+	"transactions": [],
+	"transactionsRoot": "` + emptyRoot + `",
+	"uncles": []
+}`
+
+const mainnetBlock1 = `{
+	"difficulty": "0x3ff800000",
+	"extraData": "0x476574682f76312e302e302f6c696e75782f676f312e342e32",
+	"gasLimit": "0x1388",
+	"gasUsed": "0x0",
+	"hash": "0x88e96d4537bea4d9c05d12549907b32561d3bf31f45aae734cdc119f13406cb6",
+	"logsBloom": "` + emptyBloom + `",
+	"miner": "0x05a56e2d52c817161883f50c441c3228cfe54d9f",
+	"mixHash": "0x969b900de27b6ac6a67742365dd65f55a0526c41fd18e1b16f1a1215c2e66f59",
+	"nonce": "0x539bd4979fef1ec4",
+	"number": "0x1",
+	"parentHash": "0xd4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3",
+	"receiptsRoot": "` + emptyRoot + `",
+	"sha3Uncles": "0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347",
+	"size": "0x219",
+	"stateRoot": "0xd67e4d450343046425ae4271474353857ab860dbc0a1dde64b41b5cd3a532bf3",
+	"timestamp": "0x55ba4224",
+	"totalDifficulty": "0x7ff800000",
+	"transactions": [],
+	"transactionsRoot": "` + emptyRoot + `",
+	"uncles": []
+}`
+
+// sepoliaGenesis is the first Sepolia block, which has London active
+const sepoliaGenesis = `{
+	"baseFeePerGas": "0x3b9aca00",
+	"difficulty": "0x20000",
+	"extraData": "0x5365706f6c69612c20417468656e732c204174746963612c2047726565636521",
+	"gasLimit": "0x1c9c380",
+	"gasUsed": "0x0",
+	"hash": "0x25a5cc106eea7138acab33231d7160d69cb777ee0c2c553fcddf5138993e6dd9",
+	"logsBloom": "` + emptyBloom + `",
+	"miner": "0x0000000000000000000000000000000000000000",
+	"mixHash": "0x0000000000000000000000000000000000000000000000000000000000000000",
+	"nonce": "0x0000000000000000",
+	"number": "0x0",
+	"parentHash": "0x0000000000000000000000000000000000000000000000000000000000000000",
+	"receiptsRoot": "` + emptyRoot + `",
+	"sha3Uncles": "0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347",
+	"stateRoot": "0x5eb6e371a698b8d68f665192350ffcecbbbf322916f4b51bd79bb6887da3f494",
+	"timestamp": "0x6159af19",
+	"transactions": [],
+	"transactionsRoot": "` + emptyRoot + `",
+	"uncles": []
+}`
+
+// hoodiGenesis is the first Hoodi block, which has Shanghai and Cancun active
+const hoodiGenesis = `{
+	"baseFeePerGas": "0x3b9aca00",
+	"blobGasUsed": "0x0",
+	"difficulty": "0x1",
+	"excessBlobGas": "0x0",
+	"extraData": "0x",
+	"gasLimit": "0x2255100",
+	"gasUsed": "0x0",
+	"hash": "0xbbe312868b376a3001692a646dd2d7d1e4406380dfd86b98aa8a34d1557c971b",
+	"logsBloom": "` + emptyBloom + `",
+	"miner": "0x0000000000000000000000000000000000000000",
+	"mixHash": "0x0000000000000000000000000000000000000000000000000000000000000000",
+	"nonce": "0x0000000000001234",
+	"number": "0x0",
+	"parentBeaconBlockRoot": "0x0000000000000000000000000000000000000000000000000000000000000000",
+	"parentHash": "0x0000000000000000000000000000000000000000000000000000000000000000",
+	"receiptsRoot": "` + emptyRoot + `",
+	"sha3Uncles": "0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347",
+	"stateRoot": "0xda87d7f5f91c51508791bbcbd4aa5baf04917830b86985eeb9ad3d5bfb657576",
+	"timestamp": "0x67d80ec0",
+	"transactions": [],
+	"transactionsRoot": "` + emptyRoot + `",
+	"uncles": [],
+	"withdrawals": [],
+	"withdrawalsRoot": "` + emptyRoot + `"
+}`
+
+// mustDecodeBlock decodes a block in the JSON-RPC form
+func mustDecodeBlock(t *testing.T, s string) *Block {
+	t.Helper()
+	var block Block
+	err := json.Unmarshal([]byte(s), &block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &block
+}
+
+func TestBlockForkFields(t *testing.T) {
+
+	block1 := mustDecodeBlock(t, mainnetBlock1)
+	if block1.BaseFeePerGas != nil || block1.WithdrawalsRoot != nil || block1.BlobGasUsed != nil || block1.RequestsHash != nil {
+		t.Errorf("Frontier block has fork fields: %+v", block1)
+	}
+
+	sepolia := mustDecodeBlock(t, sepoliaGenesis)
+	if sepolia.BaseFeePerGas == nil || sepolia.BaseFeePerGas.Int64() != 1000000000 || sepolia.WithdrawalsRoot != nil {
+		t.Errorf("London block: BaseFeePerGas = %v, WithdrawalsRoot = %v", sepolia.BaseFeePerGas, sepolia.WithdrawalsRoot)
+	}
+
+	hoodi := mustDecodeBlock(t, hoodiGenesis)
+	if hoodi.WithdrawalsRoot == nil || hoodi.WithdrawalsRoot.String() != emptyRoot || hoodi.Withdrawals == nil {
+		t.Errorf("Shanghai block: WithdrawalsRoot = %v, Withdrawals = %v", hoodi.WithdrawalsRoot, hoodi.Withdrawals)
+	}
+	if hoodi.BlobGasUsed == nil || hoodi.ExcessBlobGas == nil || hoodi.ParentBeaconBlockRoot == nil || hoodi.RequestsHash != nil {
+		t.Errorf("Cancun block: BlobGasUsed = %v, ExcessBlobGas = %v, ParentBeaconBlockRoot = %v, RequestsHash = %v",
+			hoodi.BlobGasUsed, hoodi.ExcessBlobGas, hoodi.ParentBeaconBlockRoot, hoodi.RequestsHash)
+	}
+
+	// the fork fields survive a round trip through the JSON-RPC form
+	b, err := json.Marshal(hoodi)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !hoodi.Equals(mustDecodeBlock(t, string(b))) {
+		t.Errorf("block changed in a JSON-RPC round trip: %s", b)
+	}
+}
+
+func TestBlockVerifyHash(t *testing.T) {
+	tests := []struct {
+		name   string
+		json   string
+		config *ChainConfig
+	}{
+		{"mainnet genesis", mainnetGenesis, MainnetChainConfig},
+		{"mainnet block 1", mainnetBlock1, MainnetChainConfig},
+		{"sepolia genesis", sepoliaGenesis, SepoliaChainConfig},
+		{"hoodi genesis", hoodiGenesis, nil},
+	}
+	for _, test := range tests {
+		block := mustDecodeBlock(t, test.json)
+		hash, err := block.ComputeHash(test.config)
+		if err != nil {
+			t.Errorf("%s: ComputeHash(): %v", test.name, err)
+			continue
+		}
+		if hash != block.Hash {
+			t.Errorf("%s: ComputeHash() = %v, want %v", test.name, hash, block.Hash)
+		}
+		if err := block.VerifyHash(test.config); err != nil {
+			t.Errorf("%s: VerifyHash(): %v", test.name, err)
+		}
+		if err := block.VerifyTransactionsRoot(); err != nil {
+			t.Errorf("%s: VerifyTransactionsRoot(): %v", test.name, err)
+		}
+		if err := block.VerifyReceiptsRoot(nil); err != nil {
+			t.Errorf("%s: VerifyReceiptsRoot(): %v", test.name, err)
+		}
+
+		// any change to the header changes the hash
+		block.GasUsed++
+		var verErr *VerificationError
+		if err := block.VerifyHash(test.config); !errors.As(err, &verErr) || verErr.Field != "Hash" {
+			t.Errorf("%s: VerifyHash() of a modified header = %v", test.name, err)
+		}
+	}
+}
+
+func TestBlockVerifyParent(t *testing.T) {
+	genesis := mustDecodeBlock(t, mainnetGenesis)
+	block1 := mustDecodeBlock(t, mainnetBlock1)
+	if err := block1.VerifyParent(genesis); err != nil {
+		t.Errorf("VerifyParent(): %v", err)
+	}
+	if err := genesis.VerifyParent(block1); err == nil {
+		t.Errorf("VerifyParent() accepted a child as the parent")
+	}
+}
+
+func TestBlockHeaderForks(t *testing.T) {
+
+	// fork fields must match the forks active under the config
+	sepolia := mustDecodeBlock(t, sepoliaGenesis)
+	if _, err := sepolia.HeaderRLP(MainnetChainConfig); err == nil {
+		t.Errorf("HeaderRLP() accepted BaseFeePerGas before London")
+	}
+	hoodi := mustDecodeBlock(t, hoodiGenesis)
+	hoodi.BlobGasUsed = nil
+	if _, err := hoodi.HeaderRLP(SepoliaChainConfig); err == nil {
+		t.Errorf("HeaderRLP() accepted a missing BlobGasUsed after Cancun")
+	}
+
+	// Prague appends the requests hash to the Cancun header
+	hoodi = mustDecodeBlock(t, hoodiGenesis)
+	cancun, err := hoodi.HeaderRLP(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	requestsHash := Hash{1}
+	hoodi.RequestsHash = &requestsHash
+	prague, err := hoodi.HeaderRLP(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// both lists have 3 byte headers, and the hash takes 33 bytes
+	if len(prague) != len(cancun)+33 || hex.EncodeToString(prague[len(prague)-33:]) != "a0"+hex.EncodeToString(requestsHash[:]) {
+		t.Errorf("HeaderRLP() = %x, want %x followed by the requests hash", prague, cancun)
+	}
+}
+
+func TestBlockVerifyWithdrawalsRoot(t *testing.T) {
+
+	hoodi := mustDecodeBlock(t, hoodiGenesis)
+	if err := hoodi.VerifyWithdrawalsRoot(); err != nil {
+		t.Errorf("VerifyWithdrawalsRoot(): %v", err)
+	}
+	hoodi.Withdrawals = []Withdrawal{{Index: 1, ValidatorIndex: 2, Amount: 3}}
+	var verErr *VerificationError
+	if err := hoodi.VerifyWithdrawalsRoot(); !errors.As(err, &verErr) || verErr.Field != "WithdrawalsRoot" {
+		t.Errorf("VerifyWithdrawalsRoot() = %v, want a WithdrawalsRoot mismatch", err)
+	}
+
+	if err := mustDecodeBlock(t, mainnetBlock1).VerifyWithdrawalsRoot(); err == nil {
+		t.Errorf("VerifyWithdrawalsRoot() accepted a block without a withdrawals root")
+	}
+}
+
+// singleItemRoot returns the root of a trie holding only the item at index 0,
+// a leaf whose key is the encoding of 0: nibbles 8 and 0, compacted to 0x2080
+func singleItemRoot(t *testing.T, item []byte) Hash {
+	t.Helper()
+	leaf, err := rlp.EncodeToBytes([]interface{}{[]byte{0x20, 0x80}, item})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return BytesToHash(crypto.Keccak256(leaf))
+}
+
+func TestBlockVerifyTransactionsRoot(t *testing.T) {
+
+	tx := mustDecodeTransaction(t, frontierTransaction)
+	enc, err := tx.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	block := mustDecodeBlock(t, mainnetBlock1)
+	block.Transactions = []Transaction{*tx}
+	block.TransactionsRoot = singleItemRoot(t, enc)
+	if err := block.VerifyTransactionsRoot(); err != nil {
+		t.Errorf("VerifyTransactionsRoot(): %v", err)
+	}
+
+	block.Transactions[0].Nonce++
+	var verErr *VerificationError
+	if err := block.VerifyTransactionsRoot(); !errors.As(err, &verErr) || verErr.Field != "TransactionsRoot" {
+		t.Errorf("VerifyTransactionsRoot() = %v, want a TransactionsRoot mismatch", err)
+	}
+
+	// transactions that can't be encoded are reported as such
+	block.Transactions[0].Type = newUint64(0x7f)
+	if err := block.VerifyTransactionsRoot(); err == nil || errors.As(err, &verErr) {
+		t.Errorf("VerifyTransactionsRoot() = %v, want an encoding error", err)
+	}
+}
+
+func TestBlockVerifyReceiptsRoot(t *testing.T) {
+
+	receipt := mustDecodeReceipt(t, blobReceipt)
+	enc, err := receipt.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	block := mustDecodeBlock(t, mainnetBlock1)
+	block.ReceiptsRoot = singleItemRoot(t, enc)
+	if err := block.VerifyReceiptsRoot([]Receipt{*receipt}); err != nil {
+		t.Errorf("VerifyReceiptsRoot(): %v", err)
+	}
+
+	var verErr *VerificationError
+	if err := block.VerifyReceiptsRoot(nil); !errors.As(err, &verErr) || verErr.Field != "ReceiptsRoot" {
+		t.Errorf("VerifyReceiptsRoot(nil) = %v, want a ReceiptsRoot mismatch", err)
+	}
+	receipt.CumulativeGasUsed++
+	if err := block.VerifyReceiptsRoot([]Receipt{*receipt}); !errors.As(err, &verErr) {
+		t.Errorf("VerifyReceiptsRoot() of a modified receipt = %v, want a mismatch", err)
+	}
+}
+
+func TestBlockVerifyParentFields(t *testing.T) {
+
+	genesis := mustDecodeBlock(t, mainnetGenesis)
+	tests := []struct {
+		field  string
+		change func(*Block)
+	}{
+		{"ParentHash", func(b *Block) { b.ParentHash = Hash{1} }},
+		{"Number", func(b *Block) { b.Number = 2 }},
+		{"Timestamp", func(b *Block) { b.Timestamp = genesis.Timestamp }},
+	}
+	for _, test := range tests {
+		block1 := mustDecodeBlock(t, mainnetBlock1)
+		test.change(block1)
+		var verErr *VerificationError
+		if err := block1.VerifyParent(genesis); !errors.As(err, &verErr) || verErr.Field != test.field {
+			t.Errorf("VerifyParent() = %v, want a %s mismatch", err, test.field)
+		}
+	}
+}
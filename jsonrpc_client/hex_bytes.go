@@ -0,0 +1,75 @@
+package jsonrpc_client
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// HexBytes is a byte string encoded in JSON as a 0x-prefixed hex string.
+// A nil HexBytes encodes as null and "0x" decodes to an empty, non-nil one.
+type HexBytes []byte
+
+// ParseHexBytes parses a 0x-prefixed hex string of even length
+func ParseHexBytes(s string) (HexBytes, error) {
+	b, err := decodeHex(s)
+	if err != nil {
+		return nil, err
+	}
+	return HexBytes(b), nil
+}
+
+// String returns the 0x-prefixed hex encoding
+func (b HexBytes) String() string {
+	return "0x" + hex.EncodeToString(b)
+}
+
+// Equal determines whether two HexBytes hold the same bytes
+func (b HexBytes) Equal(b2 HexBytes) bool {
+	return bytes.Equal(b, b2)
+}
+
+// MarshalJSON implements json.Marshaler
+func (b HexBytes) MarshalJSON() ([]byte, error) {
+	if b == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(b.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (b *HexBytes) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*b = nil
+		return nil
+	}
+	var s string
+	err := json.Unmarshal(data, &s)
+	if err != nil {
+		return fmt.Errorf("hex bytes must be a JSON string, got %s", data)
+	}
+	decoded, err := ParseHexBytes(s)
+	if err != nil {
+		return err
+	}
+	*b = decoded
+	return nil
+}
+
+// decodeFixedHex decodes a 0x-prefixed hex string of exactly len(dst) bytes
+// into dst
+func decodeFixedHex(dst []byte, s string, kind string) error {
+	if !strings.HasPrefix(s, "0x") && !strings.HasPrefix(s, "0X") {
+		return fmt.Errorf("%s %q without 0x prefix", kind, s)
+	}
+	if len(s) != 2+2*len(dst) {
+		return fmt.Errorf("%s %q has %d hex digits, want %d", kind, s, len(s)-2, 2*len(dst))
+	}
+	_, err := hex.Decode(dst, []byte(s[2:]))
+	if err != nil {
+		return fmt.Errorf("%s %q: %v", kind, s, err)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package jsonrpc_client
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestHexBytesJSON(t *testing.T) {
+
+	tests := []struct {
+		json string
+		want HexBytes
+	}{
+		{`null`, nil},
+		{`"0x"`, HexBytes{}},
+		{`"0x00"`, HexBytes{0}},
+		{`"0xdeadBEEF"`, HexBytes{0xde, 0xad, 0xbe, 0xef}},
+	}
+	for _, test := range tests {
+		var b HexBytes
+		err := json.Unmarshal([]byte(test.json), &b)
+		if err != nil || !b.Equal(test.want) || (b == nil) != (test.want == nil) {
+			t.Errorf("Unmarshal(%s) = %#v, %v, want %#v", test.json, b, err, test.want)
+		}
+	}
+
+	for _, invalid := range []string{`""`, `"deadbeef"`, `"0x1"`, `"0xzz"`, `12`} {
+		var b HexBytes
+		if err := json.Unmarshal([]byte(invalid), &b); err == nil {
+			t.Errorf("Unmarshal(%s) accepted invalid hex bytes", invalid)
+		}
+	}
+
+	out, err := json.Marshal(struct {
+		Nil   HexBytes `json:"nil"`
+		Empty HexBytes `json:"empty"`
+		Bytes HexBytes `json:"bytes"`
+	}{nil, HexBytes{}, HexBytes{0xab, 0x01}})
+	if err != nil || string(out) != `{"nil":null,"empty":"0x","bytes":"0xab01"}` {
+		t.Errorf("Marshal() = %s, %v", out, err)
+	}
+}
+
+func TestHashJSON(t *testing.T) {
+
+	const s = "0x5c504ed432cb51138bcf09aa5e8a410dd4a1e204ef84bfed1be16dfba1b22060"
+	hash, err := ParseHash(s)
+	if err != nil || hash.String() != s {
+		t.Fatalf("ParseHash() = %v, %v", hash, err)
+	}
+	upper, err := ParseHash("0x" + strings.ToUpper(s[2:]))
+	if err != nil || upper != hash {
+		t.Errorf("ParseHash(upper case) = %v, %v, want %v", upper, err, hash)
+	}
+
+	var decoded Hash
+	err = json.Unmarshal([]byte(`"`+s+`"`), &decoded)
+	if err != nil || decoded != hash {
+		t.Errorf("Unmarshal() = %v, %v", decoded, err)
+	}
+	b, err := json.Marshal(hash)
+	if err != nil || string(b) != `"`+s+`"` {
+		t.Errorf("Marshal() = %s, %v", b, err)
+	}
+
+	for _, invalid := range []string{"", "0x", s[2:], s[:65], s + "00", s[:65] + "g"} {
+		if _, err := ParseHash(invalid); err == nil {
+			t.Errorf("ParseHash(%q) accepted an invalid hash", invalid)
+		}
+	}
+
+	if BytesToHash([]byte{1}) != (Hash{31: 1}) || !(Hash{}).IsZero() || (Hash{31: 1}).Cmp(Hash{1}) != -1 {
+		t.Errorf("BytesToHash(), IsZero() or Cmp() is wrong")
+	}
+}
+
+func TestBloomJSON(t *testing.T) {
+
+	bloom, err := ParseBloom(emptyBloom)
+	if err != nil || bloom != (Bloom{}) || bloom.String() != emptyBloom {
+		t.Errorf("ParseBloom(empty) = %v, %v", bloom, err)
+	}
+
+	s := "0x01" + emptyBloom[4:]
+	err = json.Unmarshal([]byte(`"`+s+`"`), &bloom)
+	if err != nil || bloom != (Bloom{0: 1}) {
+		t.Errorf("Unmarshal() = %v, %v", bloom, err)
+	}
+	b, err := json.Marshal(bloom)
+	if err != nil || string(b) != `"`+s+`"` {
+		t.Errorf("Marshal() = %s, %v", b, err)
+	}
+
+	if _, err := ParseBloom(emptyBloom[:len(emptyBloom)-2]); err == nil {
+		t.Errorf("ParseBloom() accepted a short bloom")
+	}
+}
@@ -0,0 +1,112 @@
+package jsonrpc_client
+
+import "encoding/json"
+
+// Log is an event emitted by a contract, as returned by eth_getLogs and
+// within receipts. It is encoded in JSON in the JSON-RPC form, and in its
+// snake_case stored form by ToJSON and NewLogFromJSON.
+type Log struct {
+	Address          Address  `json:"address"`
+	Topics           []Hash   `json:"topics"`
+	Data             HexBytes `json:"data"`
+	BlockHash        *Hash    `json:"block_hash"`
+	BlockNumber      *uint64  `json:"block_number"`
+	TransactionHash  *Hash    `json:"transaction_hash"`
+	TransactionIndex *uint64  `json:"transaction_index"`
+	LogIndex         *uint64  `json:"log_index"`
+	Removed          bool     `json:"removed"`
+}
+
+// logJSON is the JSON-RPC form of a Log
+type logJSON struct {
+	Address          Address    `json:"address"`
+	Topics           []Hash     `json:"topics"`
+	Data             HexBytes   `json:"data"`
+	BlockHash        *Hash      `json:"blockHash"`        // null for pending logs
+	BlockNumber      *HexUint64 `json:"blockNumber"`      // null for pending logs
+	TransactionHash  *Hash      `json:"transactionHash"`  // null for pending logs
+	TransactionIndex *HexUint64 `json:"transactionIndex"` // null for pending logs
+	LogIndex         *HexUint64 `json:"logIndex"`         // null for pending logs
+	Removed          bool       `json:"removed"`          // true when removed by a reorg
+}
+
+// storedLog is the snake_case form of a Log
+type storedLog Log
+
+// MarshalJSON encodes the log in the JSON-RPC form
+func (log Log) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&logJSON{
+		Address:          log.Address,
+		Topics:           log.Topics,
+		Data:             log.Data,
+		BlockHash:        log.BlockHash,
+		BlockNumber:      (*HexUint64)(log.BlockNumber),
+		TransactionHash:  log.TransactionHash,
+		TransactionIndex: (*HexUint64)(log.TransactionIndex),
+		LogIndex:         (*HexUint64)(log.LogIndex),
+		Removed:          log.Removed,
+	})
+}
+
+// UnmarshalJSON decodes a log in the JSON-RPC form
+func (log *Log) UnmarshalJSON(b []byte) error {
+	var dec logJSON
+	err := json.Unmarshal(b, &dec)
+	if err != nil {
+		return err
+	}
+	*log = Log{
+		Address:          dec.Address,
+		Topics:           dec.Topics,
+		Data:             dec.Data,
+		BlockHash:        dec.BlockHash,
+		BlockNumber:      (*uint64)(dec.BlockNumber),
+		TransactionHash:  dec.TransactionHash,
+		TransactionIndex: (*uint64)(dec.TransactionIndex),
+		LogIndex:         (*uint64)(dec.LogIndex),
+		Removed:          dec.Removed,
+	}
+	return nil
+}
+
+// NewLogFromJSON creates a new Log from its snake_case stored form
+func NewLogFromJSON(b []byte) (*Log, error) {
+	var stored storedLog
+	err := json.Unmarshal(b, &stored)
+	if err != nil {
+		return nil, err
+	}
+	log := Log(stored)
+	return &log, nil
+}
+
+// ToJSON marshals a Log into its snake_case stored form
+func (log *Log) ToJSON() ([]byte, error) {
+	s, err := json.Marshal((*storedLog)(log))
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Equals determines whether two Logs are equal
+func (log *Log) Equals(log2 *Log) bool {
+
+	if log.Address != log2.Address ||
+		!log.Data.Equal(log2.Data) ||
+		log.Removed != log2.Removed ||
+		!AreEqualHashSlice(log.Topics, log2.Topics) {
+		return false
+	}
+
+	// null for pending logs
+	if !AreEqualHash(log.BlockHash, log2.BlockHash) ||
+		!AreEqualUint64(log.BlockNumber, log2.BlockNumber) ||
+		!AreEqualHash(log.TransactionHash, log2.TransactionHash) ||
+		!AreEqualUint64(log.TransactionIndex, log2.TransactionIndex) ||
+		!AreEqualUint64(log.LogIndex, log2.LogIndex) {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,79 @@
+package jsonrpc_client
+
+import (
+	"encoding/base64"
+	"net/http"
+	"time"
+)
+
+// ClientOption configures an EthereumClient
+type ClientOption func(*EthereumClient)
+
+// WithHTTPClient sets the http.Client used to issue requests
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(client *EthereumClient) {
+		client.httpClient = httpClient
+	}
+}
+
+// WithTimeout bounds the duration of every request, in addition to any
+// deadline carried by the per-call context
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(client *EthereumClient) {
+		client.timeout = timeout
+	}
+}
+
+// WithMaxResponseSize bounds the size of HTTP response bodies, beyond which
+// requests fail with ErrResponseTooLarge. The default is MAX_RESPONSE_SIZE.
+func WithMaxResponseSize(size int64) ClientOption {
+	return func(client *EthereumClient) {
+		client.maxResponseSize = size
+	}
+}
+
+// WithHeader adds a header sent with every request
+func WithHeader(key, value string) ClientOption {
+	return func(client *EthereumClient) {
+		if client.header == nil {
+			client.header = make(http.Header)
+		}
+		client.header.Add(key, value)
+	}
+}
+
+// WithBasicAuth sends HTTP basic auth credentials with every request, e.g.
+// an empty username and the Infura project secret
+func WithBasicAuth(username, password string) ClientOption {
+	credentials := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+	return func(client *EthereumClient) {
+		if client.header == nil {
+			client.header = make(http.Header)
+		}
+		client.header.Set("Authorization", "Basic "+credentials)
+	}
+}
+
+// WithDecodeMode sets how thoroughly blocks, transactions, receipts and logs
+// returned by the node are checked. The default is LenientDecoding.
+func WithDecodeMode(mode DecodeMode) ClientOption {
+	return func(client *EthereumClient) {
+		client.decodeMode = mode
+	}
+}
+
+// WithRetryPolicy retries requests failing transiently as set by policy, e.g.
+// DefaultRetryPolicy(). Requests aren't retried by default.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(client *EthereumClient) {
+		client.retry = policy
+	}
+}
+
+// WithRateLimiter spends the credits of every request, including retries,
+// from limiter, which may be shared by several clients
+func WithRateLimiter(limiter *RateLimiter) ClientOption {
+	return func(client *EthereumClient) {
+		client.limiter = limiter
+	}
+}
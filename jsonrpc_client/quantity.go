@@ -0,0 +1,112 @@
+package jsonrpc_client
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// HexUint64 is a uint64 encoded in JSON as a hex quantity: a 0x-prefixed hex
+// string without leading zeros, such as "0x0" or "0x1b4"
+type HexUint64 uint64
+
+// ParseHexUint64 parses a hex quantity of at most 64 bits
+func ParseHexUint64(s string) (uint64, error) {
+	digits, err := quantityDigits(s, 64)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(digits, 16, 64)
+}
+
+// String returns the hex quantity encoding
+func (i HexUint64) String() string {
+	return "0x" + strconv.FormatUint(uint64(i), 16)
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (i HexUint64) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (i *HexUint64) UnmarshalText(text []byte) error {
+	parsed, err := ParseHexUint64(string(text))
+	if err != nil {
+		return err
+	}
+	*i = HexUint64(parsed)
+	return nil
+}
+
+// HexBig is a non-negative big.Int of at most 256 bits encoded in JSON as a
+// hex quantity. Convert with NewHexBig and ToInt, which share the value.
+type HexBig big.Int
+
+// NewHexBig returns i as a HexBig, nil if i is nil
+func NewHexBig(i *big.Int) *HexBig {
+	return (*HexBig)(i)
+}
+
+// ParseHexBig parses a hex quantity of at most 256 bits
+func ParseHexBig(s string) (*big.Int, error) {
+	digits, err := quantityDigits(s, 256)
+	if err != nil {
+		return nil, err
+	}
+	i, _ := new(big.Int).SetString(digits, 16)
+	return i, nil
+}
+
+// ToInt returns the HexBig as a big.Int
+func (b *HexBig) ToInt() *big.Int {
+	return (*big.Int)(b)
+}
+
+// String returns the hex quantity encoding
+func (b *HexBig) String() string {
+	return "0x" + b.ToInt().Text(16)
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (b *HexBig) MarshalText() ([]byte, error) {
+	if b.ToInt().Sign() < 0 {
+		return nil, fmt.Errorf("quantity %v is negative", b.ToInt())
+	}
+	return []byte(b.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (b *HexBig) UnmarshalText(text []byte) error {
+	parsed, err := ParseHexBig(string(text))
+	if err != nil {
+		return err
+	}
+	b.ToInt().Set(parsed)
+	return nil
+}
+
+// quantityDigits returns the hex digits of a quantity holding at most bits
+// bits, rejecting a missing prefix, empty digits and leading zeros
+func quantityDigits(s string, bits int) (string, error) {
+	if !strings.HasPrefix(s, "0x") && !strings.HasPrefix(s, "0X") {
+		return "", fmt.Errorf("quantity %q without 0x prefix", s)
+	}
+	digits := s[2:]
+	if digits == "" {
+		return "", fmt.Errorf("quantity %q has no digits", s)
+	}
+	if len(digits) > 1 && digits[0] == '0' {
+		return "", fmt.Errorf("quantity %q has leading zeros", s)
+	}
+	if len(digits) > bits/4 {
+		return "", fmt.Errorf("quantity %q exceeds %d bits", s, bits)
+	}
+	for _, c := range digits {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+			return "", fmt.Errorf("quantity %q: invalid hex digit %q", s, c)
+		}
+	}
+	return digits, nil
+}
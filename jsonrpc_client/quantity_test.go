@@ -0,0 +1,237 @@
+package jsonrpc_client
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestParseHexUint64(t *testing.T) {
+	tests := []struct {
+		input string
+		want  uint64
+		err   string
+	}{
+		{"0x0", 0, ""},
+		{"0x1b4", 0x1b4, ""},
+		{"0X1B4", 0x1b4, ""},
+		{"0xffffffffffffffff", 1<<64 - 1, ""},
+		{"0x", 0, "no digits"},
+		{"", 0, "without 0x prefix"},
+		{"1b4", 0, "without 0x prefix"},
+		{"0x00", 0, "leading zeros"},
+		{"0x01b4", 0, "leading zeros"},
+		{"0x10000000000000000", 0, "exceeds 64 bits"},
+		{"0xg", 0, "invalid hex digit"},
+		{"0x-1", 0, "invalid hex digit"},
+		{"0x+1", 0, "invalid hex digit"},
+	}
+	for _, test := range tests {
+		got, err := ParseHexUint64(test.input)
+		if test.err != "" {
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("ParseHexUint64(%q) error = %v, want %q", test.input, err, test.err)
+			}
+			continue
+		}
+		if err != nil || got != test.want {
+			t.Errorf("ParseHexUint64(%q) = %d, %v, want %d", test.input, got, err, test.want)
+		}
+	}
+}
+
+func TestParseHexBig(t *testing.T) {
+	max256 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+	tests := []struct {
+		input string
+		want  *big.Int
+		err   string
+	}{
+		{"0x0", big.NewInt(0), ""},
+		{"0x2d79883d2000", big.NewInt(0x2d79883d2000), ""},
+		{"0x" + strings.Repeat("f", 64), max256, ""},
+		{"0x", nil, "no digits"},
+		{"2d79883d2000", nil, "without 0x prefix"},
+		{"0x0001", nil, "leading zeros"},
+		{"0x1" + strings.Repeat("0", 64), nil, "exceeds 256 bits"},
+		{"0x-1", nil, "invalid hex digit"},
+		{"0x_1", nil, "invalid hex digit"},
+	}
+	for _, test := range tests {
+		got, err := ParseHexBig(test.input)
+		if test.err != "" {
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("ParseHexBig(%q) error = %v, want %q", test.input, err, test.err)
+			}
+			continue
+		}
+		if err != nil || got.Cmp(test.want) != 0 {
+			t.Errorf("ParseHexBig(%q) = %v, %v, want %v", test.input, got, err, test.want)
+		}
+	}
+}
+
+func TestQuantityJSON(t *testing.T) {
+
+	var i HexUint64
+	if err := json.Unmarshal([]byte(`"0x1b4"`), &i); err != nil || i != 0x1b4 {
+		t.Errorf("HexUint64: got %d, %v", i, err)
+	}
+	if err := json.Unmarshal([]byte(`436`), &i); err == nil {
+		t.Errorf("HexUint64 accepted a JSON number")
+	}
+	if b, err := json.Marshal(HexUint64(0)); err != nil || string(b) != `"0x0"` {
+		t.Errorf("HexUint64: got %s, %v", b, err)
+	}
+
+	var b HexBig
+	if err := json.Unmarshal([]byte(`"0x2d79883d2000"`), &b); err != nil || b.ToInt().Int64() != 0x2d79883d2000 {
+		t.Errorf("HexBig: got %v, %v", b.ToInt(), err)
+	}
+	enc, err := json.Marshal(NewHexBig(big.NewInt(0)))
+	if err != nil || string(enc) != `"0x0"` {
+		t.Errorf("HexBig: got %s, %v", enc, err)
+	}
+	if _, err := json.Marshal(NewHexBig(big.NewInt(-1))); err == nil {
+		t.Errorf("HexBig encoded a negative value")
+	}
+}
+
+func FuzzHexBig(f *testing.F) {
+	for _, seed := range []string{"0x0", "0x1", "0x2d79883d2000", "0x" + strings.Repeat("f", 64), "0x", "0x00", "1"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		i, err := ParseHexBig(s)
+		if err != nil {
+			return
+		}
+		if i.Sign() < 0 || i.BitLen() > 256 {
+			t.Fatalf("ParseHexBig(%q) = %v, out of range", s, i)
+		}
+
+		// the canonical encoding parses back to the same value, and equals
+		// the input up to the case of the digits
+		enc, err := NewHexBig(i).MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.EqualFold(string(enc), s) {
+			t.Errorf("ParseHexBig(%q) encodes as %s", s, enc)
+		}
+		var decoded HexBig
+		if err := decoded.UnmarshalText(enc); err != nil || decoded.ToInt().Cmp(i) != 0 {
+			t.Errorf("%s decodes as %v, %v, want %v", enc, decoded.ToInt(), err, i)
+		}
+	})
+}
+
+// maxUint256 is the largest quantity of a JSON-RPC response
+var maxUint256 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+
+// bigSeeds are fuzzing seeds for big.Int quantities
+var bigSeeds = [][]byte{{}, {1}, {0x2d, 0x79, 0x88, 0x3d, 0x20, 0x00}, maxUint256.Bytes()}
+
+func FuzzBlockQuantities(f *testing.F) {
+	for i, seed := range []uint64{0, 1, 46147, math.MaxUint64} {
+		f.Add(seed, seed, seed, seed, seed, bigSeeds[i], bigSeeds[len(bigSeeds)-1-i])
+	}
+	f.Fuzz(func(t *testing.T, number, gasLimit, gasUsed, timestamp, nonce uint64, difficulty, totalDifficulty []byte) {
+		if len(difficulty) > 32 || len(totalDifficulty) > 32 {
+			return
+		}
+		block := &Block{
+			Number:          number,
+			GasLimit:        gasLimit,
+			GasUsed:         gasUsed,
+			Timestamp:       timestamp,
+			Size:            gasUsed,
+			Nonce:           new(big.Int).SetUint64(nonce),
+			Difficulty:      new(big.Int).SetBytes(difficulty),
+			TotalDifficulty: new(big.Int).SetBytes(totalDifficulty),
+			BaseFeePerGas:   new(big.Int).SetBytes(difficulty),
+			Transactions:    []Transaction{},
+			Uncles:          []Hash{},
+		}
+
+		// JSON-RPC decode, encode, decode
+		enc, err := json.Marshal(block)
+		if err != nil {
+			t.Fatalf("Marshal(): %v", err)
+		}
+		var decoded Block
+		if err := json.Unmarshal(enc, &decoded); err != nil {
+			t.Fatalf("Unmarshal(%s): %v", enc, err)
+		}
+		reenc, err := json.Marshal(&decoded)
+		if err != nil || !bytes.Equal(reenc, enc) {
+			t.Fatalf("encoded %s, then %s, %v", enc, reenc, err)
+		}
+		var redecoded Block
+		if err := json.Unmarshal(reenc, &redecoded); err != nil || !redecoded.Equals(block) {
+			t.Fatalf("JSON-RPC round trip changed the block: %s, %v", reenc, err)
+		}
+
+		stored, err := block.ToJSON()
+		if err != nil {
+			t.Fatalf("ToJSON(): %v", err)
+		}
+		restored, err := NewBlockFromJSON(stored)
+		if err != nil || !restored.Equals(block) {
+			t.Fatalf("stored round trip changed the block: %s, %v", stored, err)
+		}
+	})
+}
+
+func FuzzTransactionQuantities(f *testing.F) {
+	for i, seed := range []uint64{0, 1, 46147, math.MaxUint64} {
+		f.Add(seed, seed, seed, seed, bigSeeds[i], bigSeeds[len(bigSeeds)-1-i])
+	}
+	f.Fuzz(func(t *testing.T, gas, nonce, blockNumber, transactionIndex uint64, value, gasPrice []byte) {
+		if len(value) > 32 || len(gasPrice) > 32 {
+			return
+		}
+		tx := &Transaction{
+			Gas:              gas,
+			Nonce:            nonce,
+			BlockNumber:      &blockNumber,
+			TransactionIndex: &transactionIndex,
+			Value:            new(big.Int).SetBytes(value),
+			GasPrice:         new(big.Int).SetBytes(gasPrice),
+			ChainId:          new(big.Int).SetBytes(gasPrice),
+			V:                new(big.Int).SetBytes(value),
+			R:                new(big.Int).SetBytes(gasPrice),
+			S:                new(big.Int).SetBytes(value),
+		}
+
+		// JSON-RPC decode, encode, decode
+		enc, err := json.Marshal(tx)
+		if err != nil {
+			t.Fatalf("Marshal(): %v", err)
+		}
+		var decoded Transaction
+		if err := json.Unmarshal(enc, &decoded); err != nil {
+			t.Fatalf("Unmarshal(%s): %v", enc, err)
+		}
+		reenc, err := json.Marshal(&decoded)
+		if err != nil || !bytes.Equal(reenc, enc) {
+			t.Fatalf("encoded %s, then %s, %v", enc, reenc, err)
+		}
+		var redecoded Transaction
+		if err := json.Unmarshal(reenc, &redecoded); err != nil || !redecoded.Equals(tx) {
+			t.Fatalf("JSON-RPC round trip changed the transaction: %s, %v", reenc, err)
+		}
+
+		stored, err := tx.ToJSON()
+		if err != nil {
+			t.Fatalf("ToJSON(): %v", err)
+		}
+		restored, err := NewTransactionFromJSON(stored)
+		if err != nil || !restored.Equals(tx) {
+			t.Fatalf("stored round trip changed the transaction: %s, %v", stored, err)
+		}
+	})
+}
@@ -0,0 +1,163 @@
+package jsonrpc_client
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimit configures a RateLimiter. Requests spend credits from a bucket
+// refilled at PerSecond credits per second, up to Burst credits. With a
+// PerSecond of 0 or less the bucket is never refilled: Burst is a fixed
+// budget, and requests beyond it fail with ErrRateLimitExceeded.
+type RateLimit struct {
+	PerSecond float64
+	Burst     int
+
+	// Costs are the credits spent by each method, e.g. to follow a
+	// provider's credit table. Other methods cost 1 credit.
+	Costs map[string]int
+
+	// FailFast fails requests with ErrRateLimitExceeded when the bucket is
+	// short of credits, instead of waiting for it to refill
+	FailFast bool
+}
+
+// RateLimitUsage reports the state of a RateLimiter
+type RateLimitUsage struct {
+	Available float64       // credits left in the bucket, negative while requests wait for it to refill
+	Burst     int           // bucket capacity
+	Spent     uint64        // credits spent since the limiter was created
+	Requests  uint64        // requests allowed since the limiter was created, a batch counting once
+	Rejected  uint64        // requests failed with ErrRateLimitExceeded
+	Waited    time.Duration // total time requests waited for credits
+}
+
+// Utilization returns the share of the bucket in use, from 0 when full to 1
+// or more when requests are waiting, e.g. to alert before being throttled
+func (usage RateLimitUsage) Utilization() float64 {
+	if usage.Burst <= 0 {
+		return 0
+	}
+	return 1 - usage.Available/float64(usage.Burst)
+}
+
+// RateLimiter is a token bucket limiting the requests of the EthereumClients
+// sharing it, see WithRateLimiter
+type RateLimiter struct {
+	limit RateLimit
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+	usage  RateLimitUsage
+}
+
+// NewRateLimiter creates a RateLimiter with a full bucket
+func NewRateLimiter(limit RateLimit) *RateLimiter {
+	return &RateLimiter{
+		limit:  limit,
+		tokens: float64(limit.Burst),
+		last:   time.Now(),
+	}
+}
+
+// Usage returns the current state of the limiter
+func (limiter *RateLimiter) Usage() RateLimitUsage {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	limiter.refill(time.Now())
+	usage := limiter.usage
+	usage.Available = limiter.tokens
+	usage.Burst = limiter.limit.Burst
+	return usage
+}
+
+// cost returns the credits spent by a request calling methods, a batch if
+// more than one
+func (limiter *RateLimiter) cost(methods []string) float64 {
+	var cost float64
+	for _, method := range methods {
+		if methodCost, ok := limiter.limit.Costs[method]; ok {
+			cost += float64(methodCost)
+		} else {
+			cost++
+		}
+	}
+	return cost
+}
+
+// refill adds the credits earned since the last refill
+func (limiter *RateLimiter) refill(now time.Time) {
+	elapsed := now.Sub(limiter.last).Seconds()
+	limiter.last = now
+	if limiter.limit.PerSecond <= 0 {
+		return
+	}
+	limiter.tokens += elapsed * limiter.limit.PerSecond
+	if limiter.tokens > float64(limiter.limit.Burst) {
+		limiter.tokens = float64(limiter.limit.Burst)
+	}
+}
+
+// wait spends the credits of a request calling methods, waiting for the
+// bucket to refill if needed. A request costing more than the burst waits
+// for the bucket to refill from empty rather than failing forever, unless
+// the bucket is never refilled.
+func (limiter *RateLimiter) wait(ctx context.Context, methods []string) error {
+
+	cost := limiter.cost(methods)
+
+	limiter.mu.Lock()
+	limiter.refill(time.Now())
+	failFast := limiter.limit.FailFast || limiter.limit.PerSecond <= 0
+	if failFast && limiter.tokens < cost {
+		limiter.usage.Rejected++
+		limiter.mu.Unlock()
+		return ErrRateLimitExceeded
+	}
+	// reserve the credits so later requests queue behind this one
+	limiter.tokens -= cost
+	limiter.usage.Spent += uint64(cost)
+	limiter.usage.Requests++
+	var delay time.Duration
+	if limiter.tokens < 0 {
+		delay = time.Duration(-limiter.tokens / limiter.limit.PerSecond * float64(time.Second))
+	}
+	limiter.usage.Waited += delay
+	limiter.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	start := time.Now()
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		limiter.cancel(cost, delay-time.Since(start))
+		return ctx.Err()
+	}
+}
+
+// cancel returns the credits of a request that gave up waiting, with the
+// part of its delay it didn't wait
+func (limiter *RateLimiter) cancel(cost float64, unwaited time.Duration) {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	limiter.refill(time.Now())
+	limiter.tokens += cost
+	if limiter.tokens > float64(limiter.limit.Burst) {
+		limiter.tokens = float64(limiter.limit.Burst)
+	}
+	limiter.usage.Spent -= uint64(cost)
+	limiter.usage.Requests--
+	if unwaited > 0 {
+		limiter.usage.Waited -= unwaited
+	}
+}
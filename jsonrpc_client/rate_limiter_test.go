@@ -0,0 +1,184 @@
+package jsonrpc_client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterBurst(t *testing.T) {
+
+	limiter := NewRateLimiter(RateLimit{PerSecond: 0.001, Burst: 3, FailFast: true})
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		err := limiter.wait(ctx, []string{"eth_blockNumber"})
+		if err != nil {
+			t.Fatalf("request %d: %v", i, err)
+		}
+	}
+	err := limiter.wait(ctx, []string{"eth_blockNumber"})
+	if err != ErrRateLimitExceeded {
+		t.Errorf("error = %v, want ErrRateLimitExceeded", err)
+	}
+
+	usage := limiter.Usage()
+	if usage.Spent != 3 || usage.Requests != 3 || usage.Rejected != 1 || usage.Burst != 3 {
+		t.Errorf("Usage() = %+v", usage)
+	}
+	if utilization := usage.Utilization(); utilization < 0.99 {
+		t.Errorf("Utilization() = %v, want 1", utilization)
+	}
+}
+
+func TestRateLimiterRefill(t *testing.T) {
+
+	// a credit every 20ms
+	limiter := NewRateLimiter(RateLimit{PerSecond: 50, Burst: 1})
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		err := limiter.wait(ctx, []string{"eth_blockNumber"})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	// the first request spends the burst, the next 3 wait for a credit each
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("4 requests took %v, want about 60ms", elapsed)
+	}
+	if waited := limiter.Usage().Waited; waited < 50*time.Millisecond {
+		t.Errorf("Waited = %v, want about 60ms", waited)
+	}
+
+	// the bucket refills up to the burst
+	time.Sleep(100 * time.Millisecond)
+	if available := limiter.Usage().Available; available != 1 {
+		t.Errorf("Available = %v, want 1", available)
+	}
+}
+
+func TestRateLimiterCosts(t *testing.T) {
+
+	limiter := NewRateLimiter(RateLimit{
+		PerSecond: 0.001,
+		Burst:     10,
+		Costs:     map[string]int{"eth_getLogs": 5, "eth_call": 2},
+		FailFast:  true,
+	})
+	ctx := context.Background()
+
+	// a batch costs the sum of its methods
+	err := limiter.wait(ctx, []string{"eth_getLogs", "eth_call", "eth_blockNumber"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if usage := limiter.Usage(); usage.Spent != 8 || usage.Requests != 1 {
+		t.Errorf("Usage() = %+v, want 8 credits spent by 1 request", usage)
+	}
+	err = limiter.wait(ctx, []string{"eth_call", "eth_call"})
+	if err != ErrRateLimitExceeded {
+		t.Errorf("error = %v, want ErrRateLimitExceeded", err)
+	}
+	err = limiter.wait(ctx, []string{"eth_call"})
+	if err != nil {
+		t.Errorf("error = %v with 2 credits left", err)
+	}
+}
+
+func TestRateLimiterBudget(t *testing.T) {
+
+	for _, perSecond := range []float64{0, -1} {
+		// waiting doesn't help a bucket that is never refilled
+		limiter := NewRateLimiter(RateLimit{PerSecond: perSecond, Burst: 2})
+		ctx := context.Background()
+		for i := 0; i < 2; i++ {
+			err := limiter.wait(ctx, []string{"eth_blockNumber"})
+			if err != nil {
+				t.Fatalf("PerSecond %v, request %d: %v", perSecond, i, err)
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		err := limiter.wait(ctx, []string{"eth_blockNumber"})
+		if err != ErrRateLimitExceeded {
+			t.Errorf("PerSecond %v: error = %v, want ErrRateLimitExceeded", perSecond, err)
+		}
+		if usage := limiter.Usage(); usage.Available != 0 || usage.Requests != 2 {
+			t.Errorf("PerSecond %v: Usage() = %+v", perSecond, usage)
+		}
+	}
+}
+
+func TestRateLimiterCancel(t *testing.T) {
+
+	limiter := NewRateLimiter(RateLimit{PerSecond: 1, Burst: 1})
+	err := limiter.wait(context.Background(), []string{"eth_blockNumber"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err = limiter.wait(ctx, []string{"eth_blockNumber"})
+	if err != context.DeadlineExceeded {
+		t.Errorf("error = %v, want context.DeadlineExceeded", err)
+	}
+	// the credits of the request that gave up are returned
+	if usage := limiter.Usage(); usage.Requests != 1 || usage.Spent != 1 || usage.Available < -0.1 {
+		t.Errorf("Usage() = %+v", usage)
+	}
+}
+
+func TestRateLimiterCancelRefund(t *testing.T) {
+
+	// a request costing more than the burst waits 400ms for the bucket to
+	// refill from -2
+	limiter := NewRateLimiter(RateLimit{PerSecond: 5, Burst: 1, Costs: map[string]int{"eth_getLogs": 3}})
+	ctx, cancel := context.WithTimeout(context.Background(), 250*time.Millisecond)
+	defer cancel()
+	err := limiter.wait(ctx, []string{"eth_getLogs"})
+	if err != context.DeadlineExceeded {
+		t.Fatalf("error = %v, want context.DeadlineExceeded", err)
+	}
+
+	// the refund doesn't overflow the bucket, and only the time actually
+	// waited is counted
+	usage := limiter.Usage()
+	if usage.Available != 1 || usage.Spent != 0 || usage.Requests != 0 {
+		t.Errorf("Usage() = %+v, want a full bucket", usage)
+	}
+	if usage.Waited < 200*time.Millisecond || usage.Waited > 350*time.Millisecond {
+		t.Errorf("Waited = %v, want about 250ms", usage.Waited)
+	}
+}
+
+func TestClientRateLimiter(t *testing.T) {
+
+	server := newRPCServer(t, func(method string, params []json.RawMessage) (interface{}, error) {
+		return hexResult(1), nil
+	})
+	limiter := NewRateLimiter(RateLimit{
+		PerSecond: 0.001,
+		Burst:     3,
+		Costs:     map[string]int{"eth_blockNumber": 2},
+		FailFast:  true,
+	})
+	// clients sharing the limiter share its credits
+	client := NewEthereumClient(server.URL, WithRateLimiter(limiter))
+	client2 := NewEthereumClient(server.URL, WithRateLimiter(limiter))
+
+	_, err := client.Eth_blockNumber()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = client2.Eth_blockNumber()
+	if !errors.Is(err, ErrRateLimitExceeded) {
+		t.Errorf("error = %v, want ErrRateLimitExceeded", err)
+	}
+	err = client2.Call(context.Background(), "eth_chainId", nil)
+	if err != nil {
+		t.Errorf("error = %v with 1 credit left", err)
+	}
+}
@@ -0,0 +1,218 @@
+package jsonrpc_client
+
+import (
+	"encoding/json"
+	"math/big"
+)
+
+// Receipt is a transaction receipt as returned by eth_getTransactionReceipt.
+// It is encoded in JSON in the JSON-RPC form, and in its snake_case stored
+// form by ToJSON and NewReceiptFromJSON.
+type Receipt struct {
+	BlockHash         Hash     `json:"block_hash"`
+	BlockNumber       uint64   `json:"block_number"`
+	ContractAddress   *Address `json:"contract_address"`
+	CumulativeGasUsed uint64   `json:"cumulative_gas_used"`
+	EffectiveGasPrice *big.Int `json:"effective_gas_price"`
+	From              Address  `json:"from"`
+	GasUsed           uint64   `json:"gas_used"`
+	Logs              []Log    `json:"logs"`
+	LogsBloom         Bloom    `json:"logs_bloom"`
+	Root              *Hash    `json:"root"`
+	Status            *int     `json:"status"`
+	To                *Address `json:"to"`
+	TransactionHash   Hash     `json:"transaction_hash"`
+	TransactionIndex  uint64   `json:"transaction_index"`
+	Type              *uint64  `json:"type"`
+
+	// EIP-4844
+	BlobGasUsed  *uint64  `json:"blob_gas_used"`
+	BlobGasPrice *big.Int `json:"blob_gas_price"`
+}
+
+// receiptJSON is the JSON-RPC form of a Receipt
+type receiptJSON struct {
+	BlockHash         Hash       `json:"blockHash"`
+	BlockNumber       HexUint64  `json:"blockNumber"`
+	ContractAddress   *Address   `json:"contractAddress"` // null when not creating contract
+	CumulativeGasUsed HexUint64  `json:"cumulativeGasUsed"`
+	EffectiveGasPrice *HexBig    `json:"effectiveGasPrice"` // absent before London
+	From              Address    `json:"from"`
+	GasUsed           HexUint64  `json:"gasUsed"`
+	Logs              []Log      `json:"logs"`
+	LogsBloom         Bloom      `json:"logsBloom"`
+	Root              *Hash      `json:"root"`   // state root, before Byzantium
+	Status            *HexUint64 `json:"status"` // 0x1 success or 0x0 failure, since Byzantium
+	To                *Address   `json:"to"`     // null when creating contract
+	TransactionHash   Hash       `json:"transactionHash"`
+	TransactionIndex  HexUint64  `json:"transactionIndex"`
+	Type              *HexUint64 `json:"type"` // absent before Berlin
+
+	// EIP-4844
+	BlobGasUsed  *HexUint64 `json:"blobGasUsed"`
+	BlobGasPrice *HexBig    `json:"blobGasPrice"`
+}
+
+// MarshalJSON encodes the receipt in the JSON-RPC form
+func (receipt Receipt) MarshalJSON() ([]byte, error) {
+
+	var status *HexUint64
+	if receipt.Status != nil {
+		statusUint64 := HexUint64(*receipt.Status)
+		status = &statusUint64
+	}
+
+	logs := receipt.Logs
+	if logs == nil {
+		logs = []Log{}
+	}
+
+	return json.Marshal(&receiptJSON{
+		BlockHash:         receipt.BlockHash,
+		BlockNumber:       HexUint64(receipt.BlockNumber),
+		ContractAddress:   receipt.ContractAddress,
+		CumulativeGasUsed: HexUint64(receipt.CumulativeGasUsed),
+		EffectiveGasPrice: NewHexBig(receipt.EffectiveGasPrice),
+		From:              receipt.From,
+		GasUsed:           HexUint64(receipt.GasUsed),
+		Logs:              logs,
+		LogsBloom:         receipt.LogsBloom,
+		Root:              receipt.Root,
+		Status:            status,
+		To:                receipt.To,
+		TransactionHash:   receipt.TransactionHash,
+		TransactionIndex:  HexUint64(receipt.TransactionIndex),
+		Type:              (*HexUint64)(receipt.Type),
+
+		BlobGasUsed:  (*HexUint64)(receipt.BlobGasUsed),
+		BlobGasPrice: NewHexBig(receipt.BlobGasPrice),
+	})
+}
+
+// UnmarshalJSON decodes a receipt in the JSON-RPC form
+func (receipt *Receipt) UnmarshalJSON(b []byte) error {
+
+	var dec receiptJSON
+	err := json.Unmarshal(b, &dec)
+	if err != nil {
+		return err
+	}
+
+	var status *int
+	if dec.Status != nil {
+		statusInt := int(*dec.Status)
+		status = &statusInt
+	}
+
+	*receipt = Receipt{
+		BlockHash:         dec.BlockHash,
+		BlockNumber:       uint64(dec.BlockNumber),
+		ContractAddress:   dec.ContractAddress,
+		CumulativeGasUsed: uint64(dec.CumulativeGasUsed),
+		EffectiveGasPrice: dec.EffectiveGasPrice.ToInt(),
+		From:              dec.From,
+		GasUsed:           uint64(dec.GasUsed),
+		Logs:              dec.Logs,
+		LogsBloom:         dec.LogsBloom,
+		Root:              dec.Root,
+		Status:            status,
+		To:                dec.To,
+		TransactionHash:   dec.TransactionHash,
+		TransactionIndex:  uint64(dec.TransactionIndex),
+		Type:              (*uint64)(dec.Type),
+
+		BlobGasUsed:  (*uint64)(dec.BlobGasUsed),
+		BlobGasPrice: dec.BlobGasPrice.ToInt(),
+	}
+	return nil
+}
+
+// storedReceipt is the snake_case form of a Receipt. The embedded fields
+// encode by their struct tags, and the logs are replaced by their stored
+// form.
+type storedReceipt struct {
+	receiptFields
+	Logs []storedLog `json:"logs"`
+}
+
+// receiptFields has the fields of a Receipt without its JSON methods
+type receiptFields Receipt
+
+// NewReceiptFromJSON creates a new Receipt from its snake_case stored form
+func NewReceiptFromJSON(b []byte) (*Receipt, error) {
+	var stored storedReceipt
+	err := json.Unmarshal(b, &stored)
+	if err != nil {
+		return nil, err
+	}
+
+	receipt := Receipt(stored.receiptFields)
+	receipt.Logs = nil
+	if stored.Logs != nil {
+		receipt.Logs = make([]Log, len(stored.Logs))
+		for i := range stored.Logs {
+			receipt.Logs[i] = Log(stored.Logs[i])
+		}
+	}
+	return &receipt, nil
+}
+
+// ToJSON marshals a Receipt into its snake_case stored form
+func (receipt *Receipt) ToJSON() ([]byte, error) {
+
+	stored := storedReceipt{receiptFields: receiptFields(*receipt)}
+	if receipt.Logs != nil {
+		stored.Logs = make([]storedLog, len(receipt.Logs))
+		for i := range receipt.Logs {
+			stored.Logs[i] = storedLog(receipt.Logs[i])
+		}
+	}
+
+	s, err := json.Marshal(&stored)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Equals determines whether two Receipts are equal
+func (receipt *Receipt) Equals(receipt2 *Receipt) bool {
+
+	if receipt.BlockHash != receipt2.BlockHash ||
+		receipt.BlockNumber != receipt2.BlockNumber ||
+		receipt.CumulativeGasUsed != receipt2.CumulativeGasUsed ||
+		receipt.From != receipt2.From ||
+		receipt.GasUsed != receipt2.GasUsed ||
+		receipt.LogsBloom != receipt2.LogsBloom ||
+		receipt.TransactionHash != receipt2.TransactionHash ||
+		receipt.TransactionIndex != receipt2.TransactionIndex {
+		return false
+	}
+
+	// null for contract creation, or before London, Byzantium or Berlin
+	if !AreEqualAddress(receipt.ContractAddress, receipt2.ContractAddress) ||
+		!AreEqualBigInt(receipt.EffectiveGasPrice, receipt2.EffectiveGasPrice) ||
+		!AreEqualHash(receipt.Root, receipt2.Root) ||
+		!AreEqualInt(receipt.Status, receipt2.Status) ||
+		!AreEqualAddress(receipt.To, receipt2.To) ||
+		!AreEqualUint64(receipt.Type, receipt2.Type) {
+		return false
+	}
+
+	// EIP-4844
+	if !AreEqualUint64(receipt.BlobGasUsed, receipt2.BlobGasUsed) ||
+		!AreEqualBigInt(receipt.BlobGasPrice, receipt2.BlobGasPrice) {
+		return false
+	}
+
+	if len(receipt.Logs) != len(receipt2.Logs) {
+		return false
+	}
+	for i := range receipt.Logs {
+		if !receipt.Logs[i].Equals(&receipt2.Logs[i]) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,39 @@
+package jsonrpc_client
+
+import (
+	"fmt"
+
+	"github.com/INFURA/go-libs/rlp"
+)
+
+// MarshalBinary returns the consensus encoding of a receipt, as committed to
+// by receiptsRoot: the RLP list of its post-state root or status, cumulative
+// gas used, bloom and logs, prefixed by the transaction type for typed
+// transactions
+func (receipt *Receipt) MarshalBinary() ([]byte, error) {
+
+	var postState interface{}
+	switch {
+	case receipt.Status != nil:
+		postState = uint64(*receipt.Status)
+	case receipt.Root != nil:
+		// before Byzantium (EIP-658)
+		postState = receipt.Root
+	default:
+		return nil, fmt.Errorf("MarshalBinary Status: neither status nor root is set")
+	}
+
+	logs := make([]interface{}, len(receipt.Logs))
+	for i, log := range receipt.Logs {
+		logs[i] = []interface{}{log.Address, log.Topics, log.Data}
+	}
+
+	enc, err := rlp.EncodeToBytes([]interface{}{postState, receipt.CumulativeGasUsed, receipt.LogsBloom, logs})
+	if err != nil {
+		return nil, fmt.Errorf("MarshalBinary %v", err)
+	}
+	if receipt.Type == nil || *receipt.Type == LEGACY_TX_TYPE {
+		return enc, nil
+	}
+	return append([]byte{byte(*receipt.Type)}, enc...), nil
+}
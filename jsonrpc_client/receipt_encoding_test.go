@@ -0,0 +1,55 @@
+package jsonrpc_client
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/INFURA/go-libs/rlp"
+)
+
+func TestReceiptMarshalBinary(t *testing.T) {
+
+	status := 1
+	root := Hash{9}
+	to := BytesToAddress([]byte{0x35})
+	tests := []struct {
+		receipt   Receipt
+		prefix    byte
+		postState string
+	}{
+		{Receipt{Status: &status, CumulativeGasUsed: 100, Logs: []Log{{Address: to, Topics: []Hash{{1}}, Data: HexBytes{1}}}}, 0xf9, "01"},
+		{Receipt{Root: &root, CumulativeGasUsed: 5}, 0xf9, root.String()[2:]},
+		{Receipt{Status: &status, Type: newUint64(DYNAMIC_FEE_TX_TYPE), CumulativeGasUsed: 7}, DYNAMIC_FEE_TX_TYPE, "01"},
+	}
+	for i, test := range tests {
+		b, err := test.receipt.MarshalBinary()
+		if err != nil {
+			t.Fatalf("%d: %v", i, err)
+		}
+		if b[0] != test.prefix {
+			t.Errorf("%d: got prefix %#x, want %#x", i, b[0], test.prefix)
+		}
+		if test.receipt.Type != nil {
+			b = b[1:]
+		}
+
+		var fields []rlp.RawValue
+		if err := rlp.Decode(b, &fields); err != nil || len(fields) != 4 {
+			t.Fatalf("%d: got %d fields, %v", i, len(fields), err)
+		}
+		var postState []byte
+		if err := rlp.Decode(fields[0], &postState); err != nil || hex.EncodeToString(postState) != test.postState {
+			t.Errorf("%d: post state %x, want %s", i, postState, test.postState)
+		}
+		var logs []rlp.RawValue
+		if err := rlp.Decode(fields[3], &logs); err != nil || len(logs) != len(test.receipt.Logs) {
+			t.Errorf("%d: got %d logs, %v", i, len(logs), err)
+		}
+	}
+
+	_, err := (&Receipt{}).MarshalBinary()
+	if err == nil || !strings.Contains(err.Error(), "neither status nor root is set") {
+		t.Errorf("MarshalBinary() = %v", err)
+	}
+}
@@ -0,0 +1,175 @@
+package jsonrpc_client
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+)
+
+// blobReceipt is a receipt of a Cancun blob transaction which emitted a log
+const blobReceipt = `{
+	"blockHash": "0x00000000000000000000000000000000000000000000000000000000000000ab",
+	"blockNumber": "0x13a5a90",
+	"contractAddress": null,
+	"cumulativeGasUsed": "0x1a2b3c",
+	"effectiveGasPrice": "0x3b9aca00",
+	"from": "0x0000000000000000000000000000000000000001",
+	"gasUsed": "0x5208",
+	"logs": [{
+		"address": "0x0000000000000000000000000000000000000002",
+		"topics": [
+			"0x000000000000000000000000000000000000000000000000000000000000000a",
+			"0x000000000000000000000000000000000000000000000000000000000000000b"
+		],
+		"data": "0x0102",
+		"blockHash": "0x00000000000000000000000000000000000000000000000000000000000000ab",
+		"blockNumber": "0x13a5a90",
+		"transactionHash": "0x00000000000000000000000000000000000000000000000000000000000000cd",
+		"transactionIndex": "0x4",
+		"logIndex": "0x3",
+		"removed": false
+	}],
+	"logsBloom": "` + emptyBloom + `",
+	"status": "0x1",
+	"to": "0x0000000000000000000000000000000000000003",
+	"transactionHash": "0x00000000000000000000000000000000000000000000000000000000000000cd",
+	"transactionIndex": "0x4",
+	"type": "0x3",
+	"blobGasUsed": "0x20000",
+	"blobGasPrice": "0x1"
+}`
+
+// frontierReceipt is a receipt of a pre-Byzantium contract creation, with a
+// state root instead of a status and no type or effective gas price
+const frontierReceipt = `{
+	"blockHash": "0x00000000000000000000000000000000000000000000000000000000000000ef",
+	"blockNumber": "0xb443",
+	"contractAddress": "0x0000000000000000000000000000000000000004",
+	"cumulativeGasUsed": "0x5208",
+	"from": "0x0000000000000000000000000000000000000001",
+	"gasUsed": "0x5208",
+	"logs": [],
+	"logsBloom": "` + emptyBloom + `",
+	"root": "0x96a8e009d2b88b1483e6941e6812e32263b05683fac202abc622a3e31aed1957",
+	"to": null,
+	"transactionHash": "0x00000000000000000000000000000000000000000000000000000000000000cd",
+	"transactionIndex": "0x0"
+}`
+
+func mustDecodeReceipt(t *testing.T, s string) *Receipt {
+	t.Helper()
+	var receipt Receipt
+	err := json.Unmarshal([]byte(s), &receipt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &receipt
+}
+
+func TestReceiptJSON(t *testing.T) {
+
+	receipt := mustDecodeReceipt(t, blobReceipt)
+	if receipt.Status == nil || *receipt.Status != 1 || receipt.Root != nil {
+		t.Errorf("Status = %v, Root = %v, want a successful status", receipt.Status, receipt.Root)
+	}
+	if receipt.BlockNumber != 0x13a5a90 || receipt.GasUsed != 21000 || receipt.TransactionIndex != 4 {
+		t.Errorf("decoded the wrong quantities")
+	}
+	if receipt.Type == nil || *receipt.Type != BLOB_TX_TYPE ||
+		receipt.BlobGasUsed == nil || *receipt.BlobGasUsed != 0x20000 ||
+		receipt.BlobGasPrice.Cmp(big.NewInt(1)) != 0 ||
+		receipt.EffectiveGasPrice.Cmp(big.NewInt(1000000000)) != 0 {
+		t.Errorf("decoded the wrong type, gas price or blob fields")
+	}
+	if len(receipt.Logs) != 1 || receipt.Logs[0].Topics[1] != (Hash{31: 0x0b}) || *receipt.Logs[0].LogIndex != 3 {
+		t.Errorf("decoded the wrong logs: %+v", receipt.Logs)
+	}
+
+	old := mustDecodeReceipt(t, frontierReceipt)
+	if old.Status != nil || old.Root == nil || old.Type != nil || old.EffectiveGasPrice != nil || old.To != nil ||
+		old.ContractAddress == nil || *old.ContractAddress != BytesToAddress([]byte{4}) {
+		t.Errorf("decoded the wrong pre-Byzantium fields: %+v", old)
+	}
+
+	// both forms round trip
+	for _, receipt := range []*Receipt{receipt, old} {
+		b, err := json.Marshal(receipt)
+		if err != nil {
+			t.Fatalf("Marshal(): %v", err)
+		}
+		decoded := mustDecodeReceipt(t, string(b))
+		if !receipt.Equals(decoded) {
+			t.Errorf("JSON-RPC round trip changed the receipt: %s", b)
+		}
+
+		b, err = receipt.ToJSON()
+		if err != nil {
+			t.Fatalf("ToJSON(): %v", err)
+		}
+		stored, err := NewReceiptFromJSON(b)
+		if err != nil {
+			t.Fatalf("NewReceiptFromJSON(): %v", err)
+		}
+		if !receipt.Equals(stored) {
+			t.Errorf("stored round trip changed the receipt: %s", b)
+		}
+	}
+}
+
+func TestReceiptEquals(t *testing.T) {
+
+	receipt := mustDecodeReceipt(t, blobReceipt)
+	changes := []func(*Receipt){
+		func(r *Receipt) { r.GasUsed++ },
+		func(r *Receipt) { r.Status = nil },
+		func(r *Receipt) { r.EffectiveGasPrice = big.NewInt(2) },
+		func(r *Receipt) { r.BlobGasUsed = nil },
+		func(r *Receipt) { r.Logs = nil },
+		func(r *Receipt) { r.Logs[0].Removed = true },
+		func(r *Receipt) { r.Logs[0].Data = HexBytes{1} },
+	}
+	for i, change := range changes {
+		changed := mustDecodeReceipt(t, blobReceipt)
+		change(changed)
+		if receipt.Equals(changed) || changed.Equals(receipt) {
+			t.Errorf("%d: Equals() ignored a change", i)
+		}
+	}
+}
+
+func TestClientReceipts(t *testing.T) {
+
+	server := newRPCServer(t, func(method string, params []json.RawMessage) (interface{}, error) {
+		switch method {
+		case "eth_getTransactionReceipt":
+			if string(params[0]) == `"0x00000000000000000000000000000000000000000000000000000000000000cd"` {
+				return json.RawMessage(blobReceipt), nil
+			}
+		case "eth_getBlockReceipts":
+			if string(params[0]) == `"0x13a5a90"` {
+				return []json.RawMessage{json.RawMessage(frontierReceipt), json.RawMessage(blobReceipt)}, nil
+			}
+		}
+		return json.RawMessage("null"), nil
+	})
+	client := NewEthereumClient(server.URL)
+
+	receipt, err := client.Eth_getTransactionReceipt(Hash{31: 0xcd})
+	if err != nil || !receipt.Equals(mustDecodeReceipt(t, blobReceipt)) {
+		t.Errorf("Eth_getTransactionReceipt() = %+v, %v", receipt, err)
+	}
+	// unknown and pending transactions have no receipt
+	receipt, err = client.Eth_getTransactionReceipt(Hash{1})
+	if err != nil || receipt != nil {
+		t.Errorf("Eth_getTransactionReceipt(unknown) = %+v, %v, want nil", receipt, err)
+	}
+
+	receipts, err := client.Eth_getBlockReceipts(BlockAtNumber(0x13a5a90))
+	if err != nil || len(receipts) != 2 || receipts[1].TransactionIndex != 4 {
+		t.Errorf("Eth_getBlockReceipts() = %+v, %v", receipts, err)
+	}
+	receipts, err = client.Eth_getBlockReceipts(BlockAtNumber(1))
+	if err != nil || receipts != nil {
+		t.Errorf("Eth_getBlockReceipts(unknown) = %+v, %v, want nil", receipts, err)
+	}
+}
@@ -2,16 +2,50 @@ package jsonrpc_client
 
 import (
 	"encoding/json"
+	"fmt"
 )
 
 type ResponseBase struct {
-	JSONRPC string `json:"jsonrpc"`
-	ID      int64  `json:"id"`
+	JSONRPC string    `json:"jsonrpc"`
+	ID      int64     `json:"id"`
+	Error   *RPCError `json:"error,omitempty"`
+}
+
+// validate checks that the response answers the request with the given ID,
+// and returns the JSON-RPC error object if the node returned one
+func (respBase *ResponseBase) validate(requestID int64) error {
+	// nodes reply with a null ID when they couldn't parse the request, and
+	// some omit the version from error objects
+	if respBase.Error != nil && (respBase.ID == requestID || respBase.ID == 0) {
+		return respBase.Error
+	}
+	if respBase.JSONRPC != "2.0" {
+		return fmt.Errorf("%w: %q", ErrInvalidVersion, respBase.JSONRPC)
+	}
+	if respBase.ID != requestID {
+		return fmt.Errorf("%w: sent %d, received %d", ErrIDMismatch, requestID, respBase.ID)
+	}
+	return nil
 }
 
 type BlockNumberResponse struct {
 	ResponseBase
-	Result string `json:"result"`
+	Result HexUint64 `json:"result"`
+}
+
+type Uint64Response struct {
+	ResponseBase
+	Result HexUint64 `json:"result"`
+}
+
+type BigIntResponse struct {
+	ResponseBase
+	Result HexBig `json:"result"`
+}
+
+type BytesResponse struct {
+	ResponseBase
+	Result HexBytes `json:"result"`
 }
 
 type NewFilterResponse struct {
@@ -21,12 +55,12 @@ type NewFilterResponse struct {
 
 type GetFilterChangesResponse struct {
 	ResponseBase
-	Result []string `json:"result"`
+	Result []Hash `json:"result"`
 }
 
 type BlockResponse struct {
 	ResponseBase
-	Result BlockResult `json:"result"`
+	Result *Block `json:"result"` // null for unknown block
 }
 
 // ToJSON marshals a BlockResponse into JSON
@@ -40,7 +74,7 @@ func (blockResp *BlockResponse) ToJSON() ([]byte, error) {
 
 type TransactionResponse struct {
 	ResponseBase
-	Result TransactionResult `json:"result"`
+	Result *Transaction `json:"result"` // null for unknown tx
 }
 
 type StringResponse struct {
@@ -48,7 +82,43 @@ type StringResponse struct {
 	Result string `json:"result"`
 }
 
+type HashResponse struct {
+	ResponseBase
+	Result Hash `json:"result"`
+}
+
 type BoolResponse struct {
 	ResponseBase
 	Result bool `json:"result"`
 }
+
+type SyncStatusResponse struct {
+	ResponseBase
+	Result SyncStatus `json:"result"` // false once synced
+}
+
+// RawResponse is a response whose result has not been decoded yet
+type RawResponse struct {
+	ResponseBase
+	Result json.RawMessage `json:"result"`
+}
+
+type ReceiptResponse struct {
+	ResponseBase
+	Result *Receipt `json:"result"` // null for unknown or pending tx
+}
+
+type BlockReceiptsResponse struct {
+	ResponseBase
+	Result []Receipt `json:"result"` // null for unknown block
+}
+
+type LogsResponse struct {
+	ResponseBase
+	Result []Log `json:"result"`
+}
+
+type FilterChangesResponse struct {
+	ResponseBase
+	Result FilterChanges `json:"result"`
+}
@@ -0,0 +1,120 @@
+package jsonrpc_client
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestResponseValidate(t *testing.T) {
+
+	rpcErr := &RPCError{Code: PARSE_ERROR_CODE, Message: "parse error"}
+	tests := []struct {
+		name string
+		resp ResponseBase
+		want error
+	}{
+		{"matching", ResponseBase{JSONRPC: "2.0", ID: 7}, nil},
+		{"other ID", ResponseBase{JSONRPC: "2.0", ID: 8}, ErrIDMismatch},
+		{"no version", ResponseBase{ID: 7}, ErrInvalidVersion},
+		{"version 1.0", ResponseBase{JSONRPC: "1.0", ID: 7}, ErrInvalidVersion},
+		{"error", ResponseBase{JSONRPC: "2.0", ID: 7, Error: rpcErr}, rpcErr},
+		// nodes that couldn't parse the request don't know its ID
+		{"error with null ID", ResponseBase{JSONRPC: "2.0", Error: rpcErr}, rpcErr},
+		{"error with other ID", ResponseBase{JSONRPC: "2.0", ID: 8, Error: rpcErr}, ErrIDMismatch},
+		{"error without version", ResponseBase{ID: 7, Error: rpcErr}, rpcErr},
+		{"error with version 1.0", ResponseBase{JSONRPC: "1.0", Error: rpcErr}, rpcErr},
+	}
+	for _, test := range tests {
+		err := test.resp.validate(7)
+		if !errors.Is(err, test.want) {
+			t.Errorf("%s: validate() = %v, want %v", test.name, err, test.want)
+		}
+	}
+}
+
+func TestClientRequestIDs(t *testing.T) {
+
+	var mu sync.Mutex
+	seen := map[int64]bool{}
+	var last int64
+	response := `{"jsonrpc":"2.0","id":%ID%,"result":"0x1"}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		var req JSONRPCRequest
+		json.Unmarshal(body, &req)
+		mu.Lock()
+		if seen[req.ID] {
+			t.Errorf("request ID %d reused", req.ID)
+		}
+		seen[req.ID] = true
+		if req.ID > last {
+			last = req.ID
+		}
+		resp := response
+		mu.Unlock()
+		id, _ := json.Marshal(req.ID)
+		w.Write([]byte(strings.Replace(resp, "%ID%", string(id), 1)))
+	}))
+	defer server.Close()
+	client := NewEthereumClient(server.URL)
+
+	// IDs increase from one request to the next
+	var previous int64
+	for i := 0; i < 3; i++ {
+		_, err := client.Eth_blockNumber()
+		if err != nil {
+			t.Fatalf("Eth_blockNumber(): %v", err)
+		}
+		if last <= previous {
+			t.Errorf("request ID %d after %d", last, previous)
+		}
+		previous = last
+	}
+
+	// and stay unique across goroutines
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client.Eth_blockNumber()
+		}()
+	}
+	wg.Wait()
+	if len(seen) != 23 {
+		t.Errorf("server saw %d distinct IDs, want 23", len(seen))
+	}
+
+	tests := []struct {
+		response string
+		want     error
+	}{
+		{`{"jsonrpc":"2.0","id":123456,"result":"0x1"}`, ErrIDMismatch},
+		{`{"jsonrpc":"1.0","id":%ID%,"result":"0x1"}`, ErrInvalidVersion},
+		{`{"id":%ID%,"result":"0x1"}`, ErrInvalidVersion},
+	}
+	for _, test := range tests {
+		mu.Lock()
+		response = test.response
+		mu.Unlock()
+		_, err := client.Eth_blockNumber()
+		if !errors.Is(err, test.want) {
+			t.Errorf("Eth_blockNumber() with %s = %v, want %v", test.response, err, test.want)
+		}
+	}
+	// an error object without a version still reaches the caller
+	mu.Lock()
+	response = `{"id":%ID%,"error":{"code":-32000,"message":"header not found"}}`
+	mu.Unlock()
+	_, err := client.Eth_blockNumber()
+	var rpcErr *RPCError
+	if !errors.As(err, &rpcErr) || rpcErr.Message != "header not found" {
+		t.Errorf("Eth_blockNumber() = %v, want the RPC error", err)
+	}
+}
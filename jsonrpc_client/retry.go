@@ -0,0 +1,159 @@
+package jsonrpc_client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy sets how an EthereumClient retries requests failing with
+// transport errors, rate limiting and 502, 503 and 504 statuses. Requests
+// calling a non-idempotent method, which the node may have processed before
+// failing, are only retried when rate limited.
+type RetryPolicy struct {
+	MaxAttempts int           // including the first attempt, 1 or less never retries
+	MinBackoff  time.Duration // delay before the first retry, doubled for each retry
+	MaxBackoff  time.Duration // longest delay, a longer Retry-After isn't retried
+
+	// NonIdempotentMethods are the methods unsafe to send twice, e.g.
+	// eth_sendRawTransaction
+	NonIdempotentMethods map[string]bool
+}
+
+// DefaultRetryPolicy returns a policy retrying up to RETRY_MAX_ATTEMPTS
+// times, with jittered exponential backoff from RETRY_MIN_BACKOFF to
+// RETRY_MAX_BACKOFF
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: RETRY_MAX_ATTEMPTS,
+		MinBackoff:  RETRY_MIN_BACKOFF,
+		MaxBackoff:  RETRY_MAX_BACKOFF,
+		NonIdempotentMethods: map[string]bool{
+			"eth_sendRawTransaction": true,
+			"eth_sendTransaction":    true,
+			"eth_getFilterChanges":   true, // consumes the changes
+		},
+	}
+}
+
+// isIdempotent reports whether a request calling methods, a batch if more
+// than one, may be sent twice
+func (policy *RetryPolicy) isIdempotent(methods []string) bool {
+	for _, method := range methods {
+		if policy.NonIdempotentMethods[method] {
+			return false
+		}
+	}
+	return true
+}
+
+// retryDelay returns how long to wait before retrying a request that failed
+// with err, or false if it shouldn't be retried. retry counts the retries
+// already made.
+func (policy *RetryPolicy) retryDelay(err error, retry int, idempotent bool) (time.Duration, bool) {
+
+	if retry+1 >= policy.MaxAttempts || !isRetryable(err, idempotent) {
+		return 0, false
+	}
+
+	delay := policy.backoff(retry)
+	if transportErr, ok := asTransportError(err); ok {
+		if after, ok := retryAfter(transportErr.Header); ok {
+			if after > policy.MaxBackoff {
+				return 0, false
+			}
+			if after > delay {
+				delay = after
+			}
+		}
+	}
+	return delay, true
+}
+
+// backoff returns the jittered delay before a retry
+func (policy *RetryPolicy) backoff(retry int) time.Duration {
+	delay := policy.MinBackoff
+	for i := 0; i < retry && delay < policy.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > policy.MaxBackoff {
+		delay = policy.MaxBackoff
+	}
+	if delay <= 0 {
+		return 0
+	}
+	// keep at least half the delay so retries stay spread out
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// isRetryable reports whether a request failing with err may succeed if sent
+// again. Rate limiting means the request was rejected unprocessed; other
+// failures are only retried for idempotent requests.
+func isRetryable(err error, idempotent bool) bool {
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, ErrClientClosed) || errors.Is(err, ErrRateLimitExceeded) ||
+		errors.Is(err, ErrResponseTooLarge) {
+		return false
+	}
+	if IsRateLimited(err) {
+		return true
+	}
+
+	if transportErr, ok := asTransportError(err); ok {
+		switch transportErr.StatusCode {
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return idempotent
+		default:
+			// e.g. auth failures and payloads too large fail the same when
+			// sent again
+			return false
+		}
+	}
+
+	// the WebSocketClient is reconnecting and didn't send the request
+	if errors.Is(err, ErrNotConnected) {
+		return true
+	}
+
+	return idempotent
+}
+
+// retryAfter parses the Retry-After header, given in seconds or as a date
+func retryAfter(header http.Header) (time.Duration, bool) {
+
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		after := time.Until(date)
+		if after < 0 {
+			after = 0
+		}
+		return after, true
+	}
+	return 0, false
+}
+
+// rateLimitError returns the error of a response rejected by rate limiting
+// with a JSON-RPC error, as Infura does, or nil
+func rateLimitError(body []byte) error {
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 || body[0] != '{' {
+		return nil
+	}
+	var respBase ResponseBase
+	if json.Unmarshal(body, &respBase) != nil || respBase.Error == nil || !IsRateLimited(respBase.Error) {
+		return nil
+	}
+	return respBase.Error
+}
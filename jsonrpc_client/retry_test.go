@@ -0,0 +1,146 @@
+package jsonrpc_client
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		value string
+		min   time.Duration
+		max   time.Duration
+		ok    bool
+	}{
+		{"", 0, 0, false},
+		{"0", 0, 0, true},
+		{"3", 3 * time.Second, 3 * time.Second, true},
+		{"-1", 0, 0, false},
+		{"soon", 0, 0, false},
+		{time.Now().Add(5 * time.Second).UTC().Format(http.TimeFormat), 3 * time.Second, 5 * time.Second, true},
+		{time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), 0, 0, true},
+	}
+	for _, test := range tests {
+		header := http.Header{}
+		if test.value != "" {
+			header.Set("Retry-After", test.value)
+		}
+		after, ok := retryAfter(header)
+		if ok != test.ok || after < test.min || after > test.max {
+			t.Errorf("retryAfter(%q) = %v, %t, want %v to %v, %t", test.value, after, ok, test.min, test.max, test.ok)
+		}
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 20, MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	for retry, want := range []time.Duration{100, 200, 400, 800, 1000, 1000} {
+		want *= time.Millisecond
+		for i := 0; i < 20; i++ {
+			delay := policy.backoff(retry)
+			if delay < want/2 || delay > want {
+				t.Errorf("backoff(%d) = %v, want %v to %v", retry, delay, want/2, want)
+			}
+		}
+	}
+	if delay := policy.backoff(1000); delay > time.Second {
+		t.Errorf("backoff(1000) = %v, want at most MaxBackoff", delay)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+
+	policy := RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: 2 * time.Second}
+	rateLimited := func(retryAfter string) error {
+		return &TransportError{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": {retryAfter}}}
+	}
+
+	// a Retry-After within MaxBackoff replaces a shorter backoff
+	delay, ok := policy.retryDelay(rateLimited("1"), 0, false)
+	if !ok || delay != time.Second {
+		t.Errorf("retryDelay(Retry-After: 1) = %v, %t, want 1s", delay, ok)
+	}
+	// a longer Retry-After isn't retried
+	if _, ok := policy.retryDelay(rateLimited("3"), 0, true); ok {
+		t.Errorf("retryDelay(Retry-After: 3) retried beyond MaxBackoff")
+	}
+	// nor is any failure once the attempts are used up
+	if _, ok := policy.retryDelay(rateLimited("0"), 2, true); ok {
+		t.Errorf("retryDelay() retried beyond MaxAttempts")
+	}
+
+	unavailable := &TransportError{StatusCode: http.StatusServiceUnavailable}
+	if _, ok := policy.retryDelay(unavailable, 0, true); !ok {
+		t.Errorf("retryDelay() didn't retry an idempotent request failing with 503")
+	}
+	if _, ok := policy.retryDelay(unavailable, 0, false); ok {
+		t.Errorf("retryDelay() retried a non-idempotent request failing with 503")
+	}
+	if _, ok := policy.retryDelay(&TransportError{StatusCode: http.StatusUnauthorized}, 0, true); ok {
+		t.Errorf("retryDelay() retried a request failing with 401")
+	}
+	if _, ok := policy.retryDelay(errors.New("connection reset"), 0, false); ok {
+		t.Errorf("retryDelay() retried a non-idempotent request failing in transport")
+	}
+}
+
+// failingServer answers the first failures calls to any method with err, then
+// eth_blockNumber with 1 and eth_sendRawTransaction with a hash, counting the
+// calls
+func failingServer(t *testing.T, failures int32, err error) (*EthereumClient, *int32) {
+	var calls int32
+	server := newRPCServer(t, func(method string, params []json.RawMessage) (interface{}, error) {
+		if atomic.AddInt32(&calls, 1) <= failures {
+			return nil, err
+		}
+		if method == "eth_sendRawTransaction" {
+			return Hash{1}, nil
+		}
+		return hexResult(1), nil
+	})
+	policy := DefaultRetryPolicy()
+	policy.MinBackoff = time.Millisecond
+	return NewEthereumClient(server.URL, WithRetryPolicy(policy)), &calls
+}
+
+func TestClientRetry(t *testing.T) {
+
+	client, calls := failingServer(t, 2, statusError(http.StatusServiceUnavailable))
+	number, err := client.Eth_blockNumber()
+	if err != nil || number != 1 || *calls != 3 {
+		t.Errorf("Eth_blockNumber() = %d, %v after %d calls, want 1 after 3 calls", number, err, *calls)
+	}
+
+	// attempts are capped by MaxAttempts
+	client, calls = failingServer(t, 100, statusError(http.StatusBadGateway))
+	_, err = client.Eth_blockNumber()
+	if err == nil || *calls != RETRY_MAX_ATTEMPTS {
+		t.Errorf("Eth_blockNumber() = %v after %d calls, want an error after %d calls", err, *calls, RETRY_MAX_ATTEMPTS)
+	}
+}
+
+func TestClientRetrySendRawTransaction(t *testing.T) {
+
+	// the node may have processed the transaction before failing
+	client, calls := failingServer(t, 1, statusError(http.StatusServiceUnavailable))
+	_, err := client.Eth_sendRawTransaction([]byte{0xc0})
+	if err == nil || *calls != 1 {
+		t.Errorf("Eth_sendRawTransaction() = %v after %d calls, want an error after 1 call", err, *calls)
+	}
+
+	// rate limited requests were rejected unprocessed
+	client, calls = failingServer(t, 1, statusError(http.StatusTooManyRequests))
+	_, err = client.Eth_sendRawTransaction([]byte{0xc0})
+	if err != nil || *calls != 2 {
+		t.Errorf("Eth_sendRawTransaction() = %v after %d calls, want success after 2 calls", err, *calls)
+	}
+	client, calls = failingServer(t, 1, &RPCError{Code: LIMIT_EXCEEDED_CODE, Message: "daily request count exceeded"})
+	_, err = client.Eth_sendRawTransaction([]byte{0xc0})
+	if err != nil || *calls != 2 {
+		t.Errorf("Eth_sendRawTransaction() = %v after %d calls, want success after 2 calls", err, *calls)
+	}
+}
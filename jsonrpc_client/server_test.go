@@ -0,0 +1,100 @@
+package jsonrpc_client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// rpcHandler answers a JSON-RPC call with a result or an error. A
+// statusError fails the whole HTTP request instead.
+type rpcHandler func(method string, params []json.RawMessage) (interface{}, error)
+
+// statusError is returned by an rpcHandler to answer with an HTTP status and
+// a plain text body
+type statusError int
+
+// Error implements the error interface
+func (status statusError) Error() string {
+	return http.StatusText(int(status))
+}
+
+// testRequest is a JSON-RPC request as received by a test server
+type testRequest struct {
+	ID     json.RawMessage   `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+// testResponse is a JSON-RPC response sent by a test server
+type testResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *RPCError       `json:"error,omitempty"`
+}
+
+// newRPCServer starts an HTTP server answering JSON-RPC requests and batches
+// with handler, closed when the test ends
+func newRPCServer(t *testing.T, handler rpcHandler) *httptest.Server {
+	server := httptest.NewServer(rpcHTTPHandler(handler))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// rpcHTTPHandler serves JSON-RPC requests and batches with handler
+func rpcHTTPHandler(handler rpcHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var reqs []testRequest
+		batch := len(body) > 0 && body[0] == '['
+		if batch {
+			err = json.Unmarshal(body, &reqs)
+		} else {
+			reqs = make([]testRequest, 1)
+			err = json.Unmarshal(body, &reqs[0])
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		resps := make([]testResponse, len(reqs))
+		for i, req := range reqs {
+			resps[i] = testResponse{JSONRPC: "2.0", ID: req.ID}
+			result, err := handler(req.Method, req.Params)
+			if status, ok := err.(statusError); ok {
+				http.Error(w, status.Error(), int(status))
+				return
+			}
+			if rpcErr, ok := err.(*RPCError); ok {
+				resps[i].Error = rpcErr
+			} else if err != nil {
+				resps[i].Error = &RPCError{Code: -32000, Message: err.Error()}
+			} else {
+				resps[i].Result = result
+			}
+		}
+
+		w.Header().Set("Content-Type", JSON_MEDIA_TYPE)
+		if batch {
+			json.NewEncoder(w).Encode(resps)
+		} else {
+			json.NewEncoder(w).Encode(resps[0])
+		}
+	}
+}
+
+// hexResult returns i as a hex quantity result
+func hexResult(i uint64) string {
+	return fmt.Sprintf("0x%x", i)
+}
@@ -0,0 +1,251 @@
+package jsonrpc_client
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/INFURA/go-libs/crypto"
+	"github.com/INFURA/go-libs/rlp"
+)
+
+// Signer signs transactions for the chain with the given ID. Legacy
+// transactions are signed with replay protection (EIP-155) unless ChainId
+// is nil; typed transactions always require a chain ID.
+type Signer struct {
+	ChainId *big.Int
+}
+
+// NewSigner creates a new Signer for the chain with the given ID
+func NewSigner(chainId *big.Int) *Signer {
+	return &Signer{ChainId: chainId}
+}
+
+// SignTransaction signs tx with key, filling its ChainId, V, R, S, YParity,
+// From, Raw and Hash, and returns its signed consensus encoding, ready for
+// Eth_sendRawTransaction. tx is left unchanged if signing fails.
+func (signer *Signer) SignTransaction(tx *Transaction, key *crypto.PrivateKey) ([]byte, error) {
+
+	signed := *tx
+	txType := signed.txType()
+	switch {
+	case signer.ChainId == nil && txType != LEGACY_TX_TYPE:
+		return nil, fmt.Errorf("SignTransaction: type %d transactions require a chain ID", txType)
+	case signer.ChainId == nil:
+		signed.ChainId = nil
+	case signed.ChainId == nil:
+		signed.ChainId = new(big.Int).Set(signer.ChainId)
+	case signed.ChainId.Cmp(signer.ChainId) != 0:
+		return nil, fmt.Errorf("SignTransaction: transaction chain ID %v, signer chain ID %v", signed.ChainId, signer.ChainId)
+	}
+
+	hash, err := signed.SigningHash()
+	if err != nil {
+		return nil, err
+	}
+	sig, err := crypto.Sign(hash, key)
+	if err != nil {
+		return nil, fmt.Errorf("SignTransaction: %v", err)
+	}
+	recoveryID := uint64(sig[64])
+
+	switch {
+	case txType != LEGACY_TX_TYPE:
+		signed.YParity = &recoveryID
+		signed.V = new(big.Int).SetUint64(recoveryID)
+	case signed.ChainId != nil:
+		// EIP-155: V = recovery ID + 35 + 2 * chain ID
+		signed.YParity = nil
+		signed.V = new(big.Int).Lsh(signed.ChainId, 1)
+		signed.V.Add(signed.V, new(big.Int).SetUint64(recoveryID+35))
+	default:
+		signed.YParity = nil
+		signed.V = new(big.Int).SetUint64(recoveryID + 27)
+	}
+	signed.R = new(big.Int).SetBytes(sig[:32])
+	signed.S = new(big.Int).SetBytes(sig[32:64])
+	signed.From = BytesToAddress(key.Address())
+
+	raw, err := signed.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	signed.Raw = raw
+	signed.Hash = BytesToHash(crypto.Keccak256(raw))
+
+	*tx = signed
+	return raw, nil
+}
+
+// SigningHash returns the hash a transaction's signature commits to. Legacy
+// transactions with a ChainId commit to it as per EIP-155.
+func (tx *Transaction) SigningHash() ([]byte, error) {
+
+	txType := tx.txType()
+	if txType != LEGACY_TX_TYPE && tx.ChainId == nil {
+		return nil, errors.New("SigningHash: ChainId is required for typed transactions")
+	}
+
+	payload, err := tx.payload(txType)
+	if err != nil {
+		return nil, fmt.Errorf("SigningHash %v", err)
+	}
+	if txType == LEGACY_TX_TYPE && tx.ChainId != nil {
+		payload = append(payload, tx.ChainId, uint64(0), uint64(0))
+	}
+
+	enc, err := rlp.EncodeToBytes(payload)
+	if err != nil {
+		return nil, fmt.Errorf("SigningHash %v", err)
+	}
+	if txType == LEGACY_TX_TYPE {
+		return crypto.Keccak256(enc), nil
+	}
+	return crypto.Keccak256([]byte{byte(txType)}, enc), nil
+}
+
+// RecoverPublicKey recovers the public key of the account that signed the
+// transaction. The signing hash is recomputed with the chain ID encoded in V
+// for legacy transactions and ChainId for typed transactions. S must be in
+// the lower half of the curve order (EIP-2), except for unprotected legacy
+// transactions mined before Homestead under config. With a nil config, S
+// must always be low.
+func (tx *Transaction) RecoverPublicKey(config *ChainConfig) (*crypto.PublicKey, error) {
+	pub, _, err := tx.recoverSigner(config)
+	return pub, err
+}
+
+// recoverSigner recovers the signer's public key and the signature's
+// recovery ID
+func (tx *Transaction) recoverSigner(config *ChainConfig) (*crypto.PublicKey, uint64, error) {
+
+	unsigned := *tx
+	txType := tx.txType()
+	if tx.V == nil {
+		return nil, 0, errors.New("RecoverPublicKey: transaction is not signed")
+	}
+
+	var recoveryID uint64
+	lowS := true
+	switch {
+	case txType != LEGACY_TX_TYPE:
+		recoveryID = tx.V.Uint64()
+		if tx.YParity != nil {
+			if tx.V.IsUint64() && recoveryID != *tx.YParity {
+				return nil, 0, fmt.Errorf("RecoverPublicKey: V %v and YParity %d differ", tx.V, *tx.YParity)
+			}
+			recoveryID = *tx.YParity
+		}
+		if recoveryID > 1 {
+			return nil, 0, fmt.Errorf("RecoverPublicKey: invalid YParity %d", recoveryID)
+		}
+	case tx.V.Cmp(big.NewInt(27)) == 0 || tx.V.Cmp(big.NewInt(28)) == 0:
+		// unprotected legacy transactions don't commit to a chain ID
+		recoveryID = tx.V.Uint64() - 27
+		unsigned.ChainId = nil
+		// Frontier accepted any S, and pending transactions are
+		// checked against the current rules
+		lowS = config == nil || tx.BlockNumber == nil || config.IsHomestead(*tx.BlockNumber)
+	case tx.V.Cmp(big.NewInt(35)) >= 0:
+		// EIP-155: V = recovery ID + 35 + 2 * chain ID
+		v := new(big.Int).Sub(tx.V, big.NewInt(35))
+		recoveryID = v.Uint64() & 1
+		unsigned.ChainId = v.Rsh(v, 1)
+	default:
+		return nil, 0, fmt.Errorf("RecoverPublicKey: invalid V %v", tx.V)
+	}
+
+	r, s := tx.R, tx.S
+	if r == nil || s == nil {
+		return nil, 0, fmt.Errorf("RecoverPublicKey: missing R or S")
+	}
+	if !crypto.ValidateSignatureValues(byte(recoveryID), r, s, lowS) {
+		return nil, 0, fmt.Errorf("RecoverPublicKey: %v", crypto.ErrInvalidSignature)
+	}
+
+	hash, err := unsigned.SigningHash()
+	if err != nil {
+		return nil, 0, err
+	}
+	sig := make([]byte, crypto.SIGNATURE_LENGTH)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:64])
+	sig[64] = byte(recoveryID)
+
+	pub, err := crypto.RecoverPublicKey(hash, sig)
+	if err != nil {
+		return nil, 0, fmt.Errorf("RecoverPublicKey: %v", err)
+	}
+	return pub, recoveryID, nil
+}
+
+// Sender recovers the address of the account that signed the transaction,
+// see RecoverPublicKey
+func (tx *Transaction) Sender(config *ChainConfig) (Address, error) {
+	pub, err := tx.RecoverPublicKey(config)
+	if err != nil {
+		return Address{}, err
+	}
+	return BytesToAddress(pub.Address()), nil
+}
+
+// VerifySender checks that From, and the Parity PublicKey and StandardV when
+// present, match the signer recovered from the signature. Every mismatch is
+// reported in a single *VerificationErrors.
+func (tx *Transaction) VerifySender(config *ChainConfig) error {
+
+	pub, recoveryID, err := tx.recoverSigner(config)
+	if err != nil {
+		return err
+	}
+
+	var verErrs VerificationErrors
+	verErrs.verifyField("From", tx.From, BytesToAddress(pub.Address()))
+	if tx.PublicKey != nil {
+		verErrs.verifyField("PublicKey", tx.PublicKey, HexBytes(pub.Bytes()[1:]))
+	}
+	// StandardV is the recovery ID
+	if tx.StandardV != nil && uint64(*tx.StandardV) != recoveryID {
+		verErrs.Fields = append(verErrs.Fields, &VerificationError{
+			Field: "StandardV",
+			Got:   fmt.Sprint(*tx.StandardV),
+			Want:  fmt.Sprint(recoveryID),
+		})
+	}
+	if len(verErrs.Fields) > 0 {
+		return &verErrs
+	}
+	return nil
+}
+
+// SignAuthorization signs an EIP-7702 authorization with key, filling its
+// YParity, R and S. A nil ChainId is signed as 0, valid on any chain.
+func SignAuthorization(auth *Authorization, key *crypto.PrivateKey) error {
+
+	hash, err := auth.SigningHash()
+	if err != nil {
+		return err
+	}
+	sig, err := crypto.Sign(hash, key)
+	if err != nil {
+		return fmt.Errorf("SignAuthorization: %v", err)
+	}
+
+	if auth.ChainId == nil {
+		auth.ChainId = new(big.Int)
+	}
+	auth.YParity = uint64(sig[64])
+	auth.R = new(big.Int).SetBytes(sig[:32])
+	auth.S = new(big.Int).SetBytes(sig[32:64])
+	return nil
+}
+
+// SigningHash returns the hash an authorization's signature commits to
+func (auth *Authorization) SigningHash() ([]byte, error) {
+
+	enc, err := rlp.EncodeToBytes([]interface{}{auth.ChainId, auth.Address, auth.Nonce})
+	if err != nil {
+		return nil, fmt.Errorf("SigningHash %v", err)
+	}
+	return crypto.Keccak256([]byte{SET_CODE_AUTH_MAGIC}, enc), nil
+}
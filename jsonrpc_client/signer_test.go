@@ -0,0 +1,133 @@
+package jsonrpc_client
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/INFURA/go-libs/crypto"
+)
+
+// secp256k1N is the order of the secp256k1 curve
+var secp256k1N, _ = new(big.Int).SetString("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141", 16)
+
+func TestSenderFrontier(t *testing.T) {
+
+	tx := mustDecodeTransaction(t, frontierTransaction)
+	sender, err := tx.Sender(MainnetChainConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sender != tx.From {
+		t.Errorf("Sender() = %v, want %v", sender, tx.From)
+	}
+	err = tx.VerifySender(MainnetChainConfig)
+	if err != nil {
+		t.Errorf("VerifySender(): %v", err)
+	}
+}
+
+func TestSenderHighS(t *testing.T) {
+
+	// (r, n - s) with the other recovery ID is a valid signature of the same
+	// hash by the same key, but with a high S
+	tx := mustDecodeTransaction(t, frontierTransaction)
+	tx.S = new(big.Int).Sub(secp256k1N, tx.S)
+	tx.V = big.NewInt(27)
+
+	// accepted before Homestead
+	sender, err := tx.Sender(MainnetChainConfig)
+	if err != nil {
+		t.Fatalf("Frontier transaction: %v", err)
+	}
+	if sender != tx.From {
+		t.Errorf("Sender() = %v, want %v", sender, tx.From)
+	}
+
+	// rejected since Homestead, and for pending transactions
+	for _, blockNumber := range []*uint64{newUint64(1150000), nil} {
+		tx.BlockNumber = blockNumber
+		_, err = tx.Sender(MainnetChainConfig)
+		if err == nil {
+			t.Errorf("block %v: high S accepted", blockNumber)
+		}
+	}
+
+	// other chains have their own Homestead block, and without a config S
+	// must be low
+	tx.BlockNumber = newUint64(46147)
+	for _, config := range []*ChainConfig{SepoliaChainConfig, nil} {
+		_, err = tx.Sender(config)
+		if err == nil {
+			t.Errorf("config %v: high S accepted", config)
+		}
+	}
+
+	// protected transactions are all post-Homestead
+	tx.ChainId = big.NewInt(1)
+	tx.V = big.NewInt(37)
+	_, err = tx.Sender(MainnetChainConfig)
+	if err == nil {
+		t.Errorf("EIP-155 transaction: high S accepted")
+	}
+}
+
+func TestVerifySenderMismatches(t *testing.T) {
+
+	tx := mustDecodeTransaction(t, frontierTransaction)
+	tx.From = Address{}
+	standardV := 0
+	tx.StandardV = &standardV
+	pub, err := tx.RecoverPublicKey(MainnetChainConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.PublicKey = HexBytes(pub.Bytes()[1:])
+
+	err = tx.VerifySender(MainnetChainConfig)
+	var verErrs *VerificationErrors
+	if !errors.As(err, &verErrs) {
+		t.Fatalf("error = %v, want VerificationErrors", err)
+	}
+	if len(verErrs.Fields) != 2 || verErrs.Fields[0].Field != "From" || verErrs.Fields[1].Field != "StandardV" {
+		t.Errorf("error = %v, want From and StandardV mismatches", err)
+	}
+	if verErrs.Fields[1].Got != "0" || verErrs.Fields[1].Want != "1" {
+		t.Errorf("StandardV error = %v", verErrs.Fields[1])
+	}
+	var verErr *VerificationError
+	if !errors.As(err, &verErr) || verErr.Field != "From" {
+		t.Errorf("errors.As(VerificationError) = %v", verErr)
+	}
+}
+
+func TestSignRecoverTypes(t *testing.T) {
+
+	key, err := crypto.HexToPrivateKey("4646464646464646464646464646464646464646464646464646464646464646")
+	if err != nil {
+		t.Fatal(err)
+	}
+	to := BytesToAddress([]byte{0x35})
+	tests := []Transaction{
+		{Nonce: 1, GasPrice: big.NewInt(1), Gas: 21000, To: &to, Value: big.NewInt(1)},
+		{Type: newUint64(ACCESS_LIST_TX_TYPE), Nonce: 2, GasPrice: big.NewInt(1), Gas: 21000, To: &to, Value: big.NewInt(0),
+			AccessList: AccessList{{Address: to, StorageKeys: []Hash{{1}}}}},
+		{Type: newUint64(DYNAMIC_FEE_TX_TYPE), Nonce: 3, MaxFeePerGas: big.NewInt(2), MaxPriorityFeePerGas: big.NewInt(1), Gas: 21000, To: &to, Value: big.NewInt(0)},
+		{Type: newUint64(BLOB_TX_TYPE), Nonce: 4, MaxFeePerGas: big.NewInt(2), MaxPriorityFeePerGas: big.NewInt(1), Gas: 21000, To: &to, Value: big.NewInt(0),
+			MaxFeePerBlobGas: big.NewInt(1), BlobVersionedHashes: []Hash{{1}}},
+	}
+	for i := range tests {
+		tx := tests[i]
+		_, err := NewSigner(big.NewInt(1)).SignTransaction(&tx, key)
+		if err != nil {
+			t.Fatalf("type %d: %v", tx.txType(), err)
+		}
+		err = tx.VerifySender(MainnetChainConfig)
+		if err != nil {
+			t.Errorf("type %d: VerifySender(): %v", tx.txType(), err)
+		}
+		if tx.From != BytesToAddress(key.Address()) {
+			t.Errorf("type %d: From = %v", tx.txType(), tx.From)
+		}
+	}
+}
@@ -0,0 +1,335 @@
+package jsonrpc_client
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+)
+
+// Subscription is an eth_subscribe subscription whose notifications are
+// delivered on a channel. It survives reconnections of its WebSocketClient.
+type Subscription struct {
+	client *WebSocketClient
+	args   []interface{}
+	// deliver decodes a notification result and sends it to the consumer,
+	// giving up when quit is closed
+	deliver func(result json.RawMessage, quit <-chan struct{}) error
+
+	mu    sync.Mutex
+	id    string
+	ended bool
+
+	queue       chan json.RawMessage
+	err         chan error
+	quit        chan struct{}
+	forwardDone chan struct{}
+}
+
+// ID returns the node-assigned subscription ID, which changes when the
+// subscription is re-established after a reconnection
+func (sub *Subscription) ID() string {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+
+	return sub.id
+}
+
+// Err returns a channel receiving errors: notifications that could not be
+// decoded, which are dropped if nobody is receiving, and the error that ended
+// the subscription. The channel is closed once the subscription has ended.
+func (sub *Subscription) Err() <-chan error {
+	return sub.err
+}
+
+// Unsubscribe ends the subscription and calls eth_unsubscribe. It is safe to
+// call more than once.
+func (sub *Subscription) Unsubscribe() {
+	sub.unsubscribe(nil)
+}
+
+// unsubscribe ends the subscription with err and cancels it on the node
+func (sub *Subscription) unsubscribe(err error) {
+	id := sub.ID()
+	if !sub.end(err) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), WS_HANDSHAKE_TIMEOUT)
+	defer cancel()
+	sub.client.Eth_unsubscribeContext(ctx, id)
+}
+
+// end stops delivery, reports err, if any, and closes the error channel. It
+// returns false if the subscription had already ended.
+func (sub *Subscription) end(err error) bool {
+
+	sub.mu.Lock()
+	if sub.ended {
+		sub.mu.Unlock()
+		return false
+	}
+	sub.ended = true
+	id := sub.id
+	sub.mu.Unlock()
+
+	sub.client.ws.removeSubscription(id, sub)
+	close(sub.quit)
+	<-sub.forwardDone
+
+	if err != nil {
+		// make room for the final error over an unread decode error
+		select {
+		case <-sub.err:
+		default:
+		}
+		sub.err <- err
+	}
+	close(sub.err)
+	return true
+}
+
+// isEnded reports whether the subscription has ended
+func (sub *Subscription) isEnded() bool {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+
+	return sub.ended
+}
+
+// forward delivers queued notifications until the subscription ends
+func (sub *Subscription) forward() {
+	defer close(sub.forwardDone)
+
+	for {
+		select {
+		case <-sub.quit:
+			return
+		case result := <-sub.queue:
+			err := sub.deliver(result, sub.quit)
+			if err != nil {
+				select {
+				case sub.err <- err:
+				default:
+				}
+			}
+		}
+	}
+}
+
+// subscribe issues eth_subscribe for sub and routes its notifications
+func (client *WebSocketClient) subscribe(ctx context.Context, sub *Subscription) error {
+
+	if client.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, client.timeout)
+		defer cancel()
+	}
+
+	reqBody := JSONRPCRequest{
+		JSONRPC: "2.0",
+		ID:      client.nextID(),
+		Method:  "eth_subscribe",
+		Params:  sub.args,
+	}
+
+	payload, err := reqBody.ToJSON()
+	if err != nil {
+		return err
+	}
+
+	// register the subscription before its first notification is dispatched
+	register := func(body []byte) {
+		var clientResp StringResponse
+		if json.Unmarshal(body, &clientResp) != nil || clientResp.validate(reqBody.ID) != nil {
+			return
+		}
+		sub.mu.Lock()
+		defer sub.mu.Unlock()
+		if sub.ended {
+			return
+		}
+		sub.id = clientResp.Result
+		client.ws.addSubscription(clientResp.Result, sub)
+	}
+
+	body, err := client.ws.send(ctx, payload, []int64{reqBody.ID}, register)
+	if err != nil {
+		return err
+	}
+
+	var clientResp StringResponse
+	err = json.Unmarshal(body, &clientResp)
+	if err != nil {
+		return err
+	}
+	return clientResp.validate(reqBody.ID)
+}
+
+// newSubscription subscribes with the given eth_subscribe params
+func (client *WebSocketClient) newSubscription(ctx context.Context, args []interface{}, deliver func(json.RawMessage, <-chan struct{}) error) (*Subscription, error) {
+
+	sub := &Subscription{
+		client:      client,
+		args:        args,
+		deliver:     deliver,
+		queue:       make(chan json.RawMessage, WS_SUBSCRIPTION_BUFFER),
+		err:         make(chan error, 1),
+		quit:        make(chan struct{}),
+		forwardDone: make(chan struct{}),
+	}
+
+	err := client.subscribe(ctx, sub)
+	if err != nil {
+		// the node may have accepted the subscription before ctx expired
+		close(sub.forwardDone)
+		if sub.ID() != "" {
+			sub.unsubscribe(nil)
+		} else {
+			sub.end(nil)
+		}
+		return nil, err
+	}
+
+	go sub.forward()
+	return sub, nil
+}
+
+// Eth_subscribe calls the eth_subscribe JSON-RPC method and sends the raw
+// notification results to ch
+func (client *WebSocketClient) Eth_subscribe(ch chan<- json.RawMessage, args ...interface{}) (*Subscription, error) {
+	return client.Eth_subscribeContext(context.Background(), ch, args...)
+}
+
+// Eth_subscribeContext calls the eth_subscribe JSON-RPC method with the given
+// context and sends the raw notification results to ch
+func (client *WebSocketClient) Eth_subscribeContext(ctx context.Context, ch chan<- json.RawMessage, args ...interface{}) (*Subscription, error) {
+	return client.newSubscription(ctx, args, func(result json.RawMessage, quit <-chan struct{}) error {
+		select {
+		case ch <- result:
+		case <-quit:
+		}
+		return nil
+	})
+}
+
+// Eth_unsubscribe calls the eth_unsubscribe JSON-RPC method
+func (client *WebSocketClient) Eth_unsubscribe(subID string) (bool, error) {
+	return client.Eth_unsubscribeContext(context.Background(), subID)
+}
+
+// Eth_unsubscribeContext calls the eth_unsubscribe JSON-RPC method with the given context
+func (client *WebSocketClient) Eth_unsubscribeContext(ctx context.Context, subID string) (bool, error) {
+
+	reqBody := JSONRPCRequest{
+		JSONRPC: "2.0",
+		Method:  "eth_unsubscribe",
+		Params:  []interface{}{subID},
+	}
+
+	body, err := client.issueRequest(ctx, &reqBody)
+	if err != nil {
+		return false, err
+	}
+
+	var clientResp BoolResponse
+	err = json.Unmarshal(body, &clientResp)
+	if err != nil {
+		return false, err
+	}
+
+	return clientResp.Result, nil
+}
+
+// SubscribeNewHeads subscribes to newHeads and sends each new block header to ch
+func (client *WebSocketClient) SubscribeNewHeads(ch chan<- *Block) (*Subscription, error) {
+	return client.SubscribeNewHeadsContext(context.Background(), ch)
+}
+
+// SubscribeNewHeadsContext subscribes to newHeads with the given context and
+// sends each new block header to ch
+func (client *WebSocketClient) SubscribeNewHeadsContext(ctx context.Context, ch chan<- *Block) (*Subscription, error) {
+	args := []interface{}{"newHeads"}
+	return client.newSubscription(ctx, args, func(result json.RawMessage, quit <-chan struct{}) error {
+		block := new(Block)
+		err := json.Unmarshal(result, block)
+		if err != nil {
+			return err
+		}
+		select {
+		case ch <- block:
+		case <-quit:
+		}
+		return nil
+	})
+}
+
+// SubscribeLogs subscribes to logs matching q and sends each log to ch. Logs
+// removed by a reorg are sent again with Removed set.
+func (client *WebSocketClient) SubscribeLogs(ch chan<- *Log, q FilterQuery) (*Subscription, error) {
+	return client.SubscribeLogsContext(context.Background(), ch, q)
+}
+
+// SubscribeLogsContext subscribes to logs matching q with the given context
+// and sends each log to ch
+func (client *WebSocketClient) SubscribeLogsContext(ctx context.Context, ch chan<- *Log, q FilterQuery) (*Subscription, error) {
+	args := []interface{}{"logs", q}
+	return client.newSubscription(ctx, args, func(result json.RawMessage, quit <-chan struct{}) error {
+		log := new(Log)
+		err := json.Unmarshal(result, log)
+		if err != nil {
+			return err
+		}
+		select {
+		case ch <- log:
+		case <-quit:
+		}
+		return nil
+	})
+}
+
+// SubscribeNewPendingTransactions subscribes to newPendingTransactions and
+// sends each transaction hash to ch
+func (client *WebSocketClient) SubscribeNewPendingTransactions(ch chan<- Hash) (*Subscription, error) {
+	return client.SubscribeNewPendingTransactionsContext(context.Background(), ch)
+}
+
+// SubscribeNewPendingTransactionsContext subscribes to newPendingTransactions
+// with the given context and sends each transaction hash to ch
+func (client *WebSocketClient) SubscribeNewPendingTransactionsContext(ctx context.Context, ch chan<- Hash) (*Subscription, error) {
+	args := []interface{}{"newPendingTransactions"}
+	return client.newSubscription(ctx, args, func(result json.RawMessage, quit <-chan struct{}) error {
+		var txHash Hash
+		err := json.Unmarshal(result, &txHash)
+		if err != nil {
+			return err
+		}
+		select {
+		case ch <- txHash:
+		case <-quit:
+		}
+		return nil
+	})
+}
+
+// SubscribeSyncing subscribes to syncing and sends each status change to ch
+func (client *WebSocketClient) SubscribeSyncing(ch chan<- *SyncStatus) (*Subscription, error) {
+	return client.SubscribeSyncingContext(context.Background(), ch)
+}
+
+// SubscribeSyncingContext subscribes to syncing with the given context and
+// sends each status change to ch
+func (client *WebSocketClient) SubscribeSyncingContext(ctx context.Context, ch chan<- *SyncStatus) (*Subscription, error) {
+	args := []interface{}{"syncing"}
+	return client.newSubscription(ctx, args, func(result json.RawMessage, quit <-chan struct{}) error {
+		var status SyncStatus
+		err := json.Unmarshal(result, &status)
+		if err != nil {
+			return err
+		}
+		select {
+		case ch <- &status:
+		case <-quit:
+		}
+		return nil
+	})
+}
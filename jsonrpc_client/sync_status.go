@@ -0,0 +1,79 @@
+package jsonrpc_client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SyncStatus is the sync progress of a node, as reported by eth_syncing and
+// the syncing subscription
+type SyncStatus struct {
+	Syncing       bool   `json:"syncing"`
+	StartingBlock uint64 `json:"starting_block"`
+	CurrentBlock  uint64 `json:"current_block"`
+	HighestBlock  uint64 `json:"highest_block"`
+}
+
+// UnmarshalJSON decodes the forms nodes use for sync progress: a bare
+// boolean, the progress object of eth_syncing, or a subscription object whose
+// status block numbers are hex strings (Parity) or plain numbers with
+// capitalized keys (Geth)
+func (status *SyncStatus) UnmarshalJSON(b []byte) error {
+
+	b = bytes.TrimSpace(b)
+	if len(b) > 0 && b[0] != '{' {
+		*status = SyncStatus{}
+		return json.Unmarshal(b, &status.Syncing)
+	}
+
+	var raw struct {
+		Syncing *bool                      `json:"syncing"`
+		Status  map[string]json.RawMessage `json:"status"`
+	}
+	err := json.Unmarshal(b, &raw)
+	if err != nil {
+		return err
+	}
+
+	// eth_syncing returns the progress itself, and false once synced
+	if raw.Syncing == nil {
+		syncing := true
+		raw.Syncing = &syncing
+		err = json.Unmarshal(b, &raw.Status)
+		if err != nil {
+			return err
+		}
+	}
+
+	*status = SyncStatus{Syncing: *raw.Syncing}
+	for key, value := range raw.Status {
+		var field *uint64
+		switch strings.ToLower(key) {
+		case "startingblock":
+			field = &status.StartingBlock
+		case "currentblock":
+			field = &status.CurrentBlock
+		case "highestblock":
+			field = &status.HighestBlock
+		default:
+			continue
+		}
+		*field, err = parseSyncNumber(value)
+		if err != nil {
+			return fmt.Errorf("SyncStatus %s: %v", key, err)
+		}
+	}
+	return nil
+}
+
+// parseSyncNumber parses a block number given as a JSON number or hex string
+func parseSyncNumber(value json.RawMessage) (uint64, error) {
+	var s string
+	if json.Unmarshal(value, &s) != nil {
+		s = string(value)
+	}
+	return strconv.ParseUint(s, 0, 64)
+}
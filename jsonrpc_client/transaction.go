@@ -3,130 +3,261 @@ package jsonrpc_client
 import (
 	"encoding/json"
 	"math/big"
-	"strconv"
 )
 
+// Transaction is a transaction as returned by eth_getTransactionByHash and
+// within blocks. It is encoded in JSON in the JSON-RPC form, and in its
+// snake_case stored form by ToJSON and NewTransactionFromJSON.
 type Transaction struct {
-	BlockHash        *string  `json:"block_hash"`
-	BlockNumber      *int     `json:"block_number"`
-	From             string   `json:"from"`
-	Gas              int      `json:"gas"`
+	BlockHash        *Hash    `json:"block_hash"`
+	BlockNumber      *uint64  `json:"block_number"`
+	From             Address  `json:"from"`
+	Gas              uint64   `json:"gas"`
 	GasPrice         *big.Int `json:"gas_price"`
-	Hash             string   `json:"hash"`
-	Input            string   `json:"input"`
-	Nonce            int      `json:"nonce"`
-	R                string   `json:"r"`
-	S                string   `json:"s"`
-	To               *string  `json:"to"`
-	TransactionIndex *int     `json:"transaction_index"`
-	V                int      `json:"v"`
+	Hash             Hash     `json:"hash"`
+	Input            HexBytes `json:"input"`
+	Nonce            uint64   `json:"nonce"`
+	R                *big.Int `json:"r"`
+	S                *big.Int `json:"s"`
+	To               *Address `json:"to"`
+	TransactionIndex *uint64  `json:"transaction_index"`
+	V                *big.Int `json:"v"`
 	Value            *big.Int `json:"value"`
 
+	// EIP-155 and EIP-2718
+	ChainId *big.Int `json:"chain_id"`
+	Type    *uint64  `json:"type"`
+
+	// EIP-2930
+	AccessList AccessList `json:"access_list"`
+
+	// EIP-1559
+	MaxFeePerGas         *big.Int `json:"max_fee_per_gas"`
+	MaxPriorityFeePerGas *big.Int `json:"max_priority_fee_per_gas"`
+	YParity              *uint64  `json:"y_parity"`
+
+	// EIP-4844
+	MaxFeePerBlobGas    *big.Int `json:"max_fee_per_blob_gas"`
+	BlobVersionedHashes []Hash   `json:"blob_versioned_hashes"`
+
+	// EIP-7702
+	AuthorizationList []Authorization `json:"authorization_list"`
+
 	// Parity only
-	Condition *string `json:"condition"`
-	ChainId   *int    `json:"chain_id"`
-	Creates   *string `json:"creates"`
-	PublicKey *string `json:"public_key"`
-	Raw       *string `json:"raw"`
-	StandardV *int    `json:"standard_v"`
+	Condition *string  `json:"condition"`
+	Creates   *Address `json:"creates"`
+	PublicKey HexBytes `json:"public_key"`
+	Raw       HexBytes `json:"raw"`
+	StandardV *int     `json:"standard_v"`
 }
 
-// NewTransactionFromJSON creates a new Transaction from JSON
-func NewTransactionFromJSON(b []byte) (*Transaction, error) {
-	tx := Transaction{}
-	err := json.Unmarshal(b, &tx)
-	if err != nil {
-		return nil, err
-	}
-	return &tx, nil
+// transactionJSON is the JSON-RPC form of a Transaction
+type transactionJSON struct {
+	BlockHash        *Hash      `json:"blockHash"`   // null for pending tx
+	BlockNumber      *HexUint64 `json:"blockNumber"` // null for pending tx
+	From             Address    `json:"from"`
+	Gas              HexUint64  `json:"gas"`
+	GasPrice         *HexBig    `json:"gasPrice"`
+	Hash             Hash       `json:"hash"`
+	Input            HexBytes   `json:"input"`
+	Nonce            HexUint64  `json:"nonce"`
+	R                *HexBig    `json:"r"`
+	S                *HexBig    `json:"s"`
+	To               *Address   `json:"to"`               // null when creating contract
+	TransactionIndex *HexUint64 `json:"transactionIndex"` // null for pending tx
+	V                *HexBig    `json:"v"`
+	Value            *HexBig    `json:"value"`
+
+	// EIP-155 and EIP-2718
+	ChainId *HexBig    `json:"chainId"` // null for legacy txs without replay protection
+	Type    *HexUint64 `json:"type"`    // absent before Berlin
+
+	// EIP-2930, null for legacy txs
+	AccessList AccessList `json:"accessList"`
+
+	// EIP-1559
+	MaxFeePerGas         *HexBig    `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *HexBig    `json:"maxPriorityFeePerGas"`
+	YParity              *HexUint64 `json:"yParity"` // same as v for typed txs
+
+	// EIP-4844
+	MaxFeePerBlobGas    *HexBig `json:"maxFeePerBlobGas"`
+	BlobVersionedHashes []Hash  `json:"blobVersionedHashes"`
+
+	// EIP-7702
+	AuthorizationList []Authorization `json:"authorizationList"`
+
+	// Parity only
+	Condition *string    `json:"condition"` // unknown type
+	Creates   *Address   `json:"creates"`   // null when not creating contract
+	PublicKey HexBytes   `json:"publicKey"`
+	Raw       HexBytes   `json:"raw"`
+	StandardV *HexUint64 `json:"standardV"`
 }
 
-// ToTransactionResult converts a Transaction to a TransactionResult
-func (tx *Transaction) ToTransactionResult() (*TransactionResult, error) {
+// MarshalJSON encodes the transaction in the JSON-RPC form
+func (tx Transaction) MarshalJSON() ([]byte, error) {
 
-	// pointers
-	var blockHash, blockNumber, to, transactionIndex *string
-	if tx.BlockHash != nil {
-		blockHashString := *tx.BlockHash // store our own copy
-		blockHash = &blockHashString
-	}
-	if tx.BlockNumber != nil {
-		blockNumberString := "0x" + strconv.FormatInt(int64(*tx.BlockNumber), 16)
-		blockNumber = &blockNumberString
-	}
-	if tx.To != nil {
-		toString := *tx.To // store our own copy
-		to = &toString
+	var standardV *HexUint64
+	if tx.StandardV != nil {
+		standardVUint64 := HexUint64(*tx.StandardV)
+		standardV = &standardVUint64
 	}
-	if tx.TransactionIndex != nil {
-		transactionIndexString := "0x" + strconv.FormatInt(int64(*tx.TransactionIndex), 16)
-		transactionIndex = &transactionIndexString
+
+	enc := transactionJSON{
+		BlockHash:        tx.BlockHash,
+		BlockNumber:      (*HexUint64)(tx.BlockNumber),
+		From:             tx.From,
+		Gas:              HexUint64(tx.Gas),
+		GasPrice:         NewHexBig(tx.GasPrice),
+		Hash:             tx.Hash,
+		Input:            tx.Input,
+		Nonce:            HexUint64(tx.Nonce),
+		R:                NewHexBig(tx.R),
+		S:                NewHexBig(tx.S),
+		To:               tx.To,
+		TransactionIndex: (*HexUint64)(tx.TransactionIndex),
+		V:                NewHexBig(tx.V),
+		Value:            NewHexBig(tx.Value),
+
+		ChainId: NewHexBig(tx.ChainId),
+		Type:    (*HexUint64)(tx.Type),
+
+		AccessList: tx.AccessList,
+
+		MaxFeePerGas:         NewHexBig(tx.MaxFeePerGas),
+		MaxPriorityFeePerGas: NewHexBig(tx.MaxPriorityFeePerGas),
+		YParity:              (*HexUint64)(tx.YParity),
+
+		MaxFeePerBlobGas:    NewHexBig(tx.MaxFeePerBlobGas),
+		BlobVersionedHashes: tx.BlobVersionedHashes,
+
+		AuthorizationList: tx.AuthorizationList,
+
+		Condition: tx.Condition,
+		Creates:   tx.Creates,
+		PublicKey: tx.PublicKey,
+		Raw:       tx.Raw,
+		StandardV: standardV,
 	}
+	return json.Marshal(&enc)
+}
 
-	gas := "0x" + strconv.FormatInt(int64(tx.Gas), 16)
-	gasPrice := "0x" + tx.GasPrice.Text(16)
-	nonce := "0x" + strconv.FormatInt(int64(tx.Nonce), 16)
-	v := "0x" + strconv.FormatInt(int64(tx.V), 16)
-	value := "0x" + tx.Value.Text(16)
+// UnmarshalJSON decodes a transaction in the JSON-RPC form
+func (tx *Transaction) UnmarshalJSON(b []byte) error {
 
-	// Parity only
-	var condition, creates, publicKey, standardV, raw *string
-	var chainId *int
-	if tx.ChainId != nil {
-		chainIdInt := *tx.ChainId
-		chainId = &chainIdInt
+	var dec transactionJSON
+	err := json.Unmarshal(b, &dec)
+	if err != nil {
+		return err
 	}
-	if tx.Condition != nil {
-		conditionString := *tx.Condition
-		condition = &conditionString
+
+	var standardV *int
+	if dec.StandardV != nil {
+		standardVInt := int(*dec.StandardV)
+		standardV = &standardVInt
 	}
-	if tx.Creates != nil {
-		createsString := *tx.Creates
-		creates = &createsString
+
+	*tx = Transaction{
+		BlockHash:        dec.BlockHash,
+		BlockNumber:      (*uint64)(dec.BlockNumber),
+		From:             dec.From,
+		Gas:              uint64(dec.Gas),
+		GasPrice:         dec.GasPrice.ToInt(),
+		Hash:             dec.Hash,
+		Input:            dec.Input,
+		Nonce:            uint64(dec.Nonce),
+		R:                dec.R.ToInt(),
+		S:                dec.S.ToInt(),
+		To:               dec.To,
+		TransactionIndex: (*uint64)(dec.TransactionIndex),
+		V:                dec.V.ToInt(),
+		Value:            dec.Value.ToInt(),
+
+		ChainId: dec.ChainId.ToInt(),
+		Type:    (*uint64)(dec.Type),
+
+		AccessList: dec.AccessList,
+
+		MaxFeePerGas:         dec.MaxFeePerGas.ToInt(),
+		MaxPriorityFeePerGas: dec.MaxPriorityFeePerGas.ToInt(),
+		YParity:              (*uint64)(dec.YParity),
+
+		MaxFeePerBlobGas:    dec.MaxFeePerBlobGas.ToInt(),
+		BlobVersionedHashes: dec.BlobVersionedHashes,
+
+		AuthorizationList: dec.AuthorizationList,
+
+		Condition: dec.Condition,
+		Creates:   dec.Creates,
+		PublicKey: dec.PublicKey,
+		Raw:       dec.Raw,
+		StandardV: standardV,
 	}
-	if tx.PublicKey != nil {
-		publicKeyString := *tx.PublicKey
-		publicKey = &publicKeyString
+	return nil
+}
+
+// storedTransaction is the snake_case form of a Transaction. The embedded
+// fields encode by their struct tags, and the authorizations are replaced by
+// their stored form. The signature values R and S are hex quantities, as
+// they were stored as strings before they were typed.
+type storedTransaction struct {
+	transactionFields
+	R                 *HexBig               `json:"r"`
+	S                 *HexBig               `json:"s"`
+	AuthorizationList []storedAuthorization `json:"authorization_list"`
+}
+
+// transactionFields has the fields of a Transaction without its JSON methods
+type transactionFields Transaction
+
+// newStoredTransaction returns the stored form of tx
+func newStoredTransaction(tx *Transaction) storedTransaction {
+	stored := storedTransaction{
+		transactionFields: transactionFields(*tx),
+		R:                 NewHexBig(tx.R),
+		S:                 NewHexBig(tx.S),
 	}
-	if tx.Raw != nil {
-		rawString := *tx.Raw
-		raw = &rawString
+	if tx.AuthorizationList != nil {
+		stored.AuthorizationList = make([]storedAuthorization, len(tx.AuthorizationList))
+		for i := range tx.AuthorizationList {
+			stored.AuthorizationList[i] = storedAuthorization(tx.AuthorizationList[i])
+		}
 	}
-	if tx.StandardV != nil {
-		standardVString := "0x" + strconv.FormatInt(int64(*tx.StandardV), 16)
-		standardV = &standardVString
+	return stored
+}
+
+// transaction returns the Transaction of its stored form
+func (stored *storedTransaction) transaction() Transaction {
+	tx := Transaction(stored.transactionFields)
+	tx.R = stored.R.ToInt()
+	tx.S = stored.S.ToInt()
+	tx.AuthorizationList = nil
+	if stored.AuthorizationList != nil {
+		tx.AuthorizationList = make([]Authorization, len(stored.AuthorizationList))
+		for i := range stored.AuthorizationList {
+			tx.AuthorizationList[i] = Authorization(stored.AuthorizationList[i])
+		}
 	}
+	return tx
+}
 
-	txResult := TransactionResult{
-		BlockHash:        blockHash,
-		BlockNumber:      blockNumber,
-		From:             tx.From,
-		Gas:              gas,
-		GasPrice:         gasPrice,
-		Hash:             tx.Hash,
-		Input:            tx.Input,
-		Nonce:            nonce,
-		R:                tx.R,
-		S:                tx.S,
-		To:               to,
-		TransactionIndex: transactionIndex,
-		V:                v,
-		Value:            value,
-
-		// Parity only
-		Condition: condition,
-		ChainId:   chainId,
-		Creates:   creates,
-		PublicKey: publicKey,
-		Raw:       raw,
-		StandardV: standardV,
+// NewTransactionFromJSON creates a new Transaction from its snake_case
+// stored form
+func NewTransactionFromJSON(b []byte) (*Transaction, error) {
+	var stored storedTransaction
+	err := json.Unmarshal(b, &stored)
+	if err != nil {
+		return nil, err
 	}
-	return &txResult, nil
+	tx := stored.transaction()
+	return &tx, nil
 }
 
-// ToJSON marshals a Transaction into JSON
+// ToJSON marshals a Transaction into its snake_case stored form
 func (tx *Transaction) ToJSON() ([]byte, error) {
-	s, err := json.Marshal(tx)
+	stored := newStoredTransaction(tx)
+	s, err := json.Marshal(&stored)
 	if err != nil {
 		return nil, err
 	}
@@ -139,38 +270,51 @@ func (tx *Transaction) Equals(tx2 *Transaction) bool {
 	if tx.From != tx2.From ||
 		tx.Gas != tx2.Gas ||
 		tx.Hash != tx2.Hash ||
-		tx.Input != tx2.Input ||
-		tx.Nonce != tx2.Nonce ||
-		tx.R != tx2.R ||
-		tx.S != tx2.S ||
-		tx.V != tx2.V {
+		!tx.Input.Equal(tx2.Input) ||
+		tx.Nonce != tx2.Nonce {
 		return false
 	}
 
 	// big integers
 	if !AreEqualBigInt(tx.GasPrice, tx2.GasPrice) ||
+		!AreEqualBigInt(tx.R, tx2.R) ||
+		!AreEqualBigInt(tx.S, tx2.S) ||
+		!AreEqualBigInt(tx.V, tx2.V) ||
 		!AreEqualBigInt(tx.Value, tx2.Value) {
 		return false
 	}
 
 	// confirmed tx
-	if !AreEqualString(tx.BlockHash, tx2.BlockHash) ||
-		!AreEqualInt(tx.BlockNumber, tx2.BlockNumber) ||
-		!AreEqualInt(tx.TransactionIndex, tx2.TransactionIndex) {
+	if !AreEqualHash(tx.BlockHash, tx2.BlockHash) ||
+		!AreEqualUint64(tx.BlockNumber, tx2.BlockNumber) ||
+		!AreEqualUint64(tx.TransactionIndex, tx2.TransactionIndex) {
 		return false
 	}
 
 	// null for contract creation
-	if !AreEqualString(tx.To, tx2.To) {
+	if !AreEqualAddress(tx.To, tx2.To) {
+		return false
+	}
+
+	// typed transactions
+	if !AreEqualBigInt(tx.ChainId, tx2.ChainId) ||
+		!AreEqualUint64(tx.Type, tx2.Type) ||
+		!tx.AccessList.Equals(tx2.AccessList) ||
+		!AreEqualBigInt(tx.MaxFeePerGas, tx2.MaxFeePerGas) ||
+		!AreEqualBigInt(tx.MaxPriorityFeePerGas, tx2.MaxPriorityFeePerGas) ||
+		!AreEqualUint64(tx.YParity, tx2.YParity) ||
+		!AreEqualBigInt(tx.MaxFeePerBlobGas, tx2.MaxFeePerBlobGas) ||
+		(tx.BlobVersionedHashes == nil) != (tx2.BlobVersionedHashes == nil) ||
+		!AreEqualHashSlice(tx.BlobVersionedHashes, tx2.BlobVersionedHashes) ||
+		!AreEqualAuthorizations(tx.AuthorizationList, tx2.AuthorizationList) {
 		return false
 	}
 
 	// Parity only
-	if !AreEqualInt(tx.ChainId, tx2.ChainId) ||
-		!AreEqualString(tx.Condition, tx2.Condition) ||
-		!AreEqualString(tx.Creates, tx2.Creates) ||
-		!AreEqualString(tx.PublicKey, tx2.PublicKey) ||
-		!AreEqualString(tx.Raw, tx2.Raw) ||
+	if !AreEqualString(tx.Condition, tx2.Condition) ||
+		!AreEqualAddress(tx.Creates, tx2.Creates) ||
+		!AreEqualHexBytes(tx.PublicKey, tx2.PublicKey) ||
+		!AreEqualHexBytes(tx.Raw, tx2.Raw) ||
 		!AreEqualInt(tx.StandardV, tx2.StandardV) {
 		return false
 	}
@@ -0,0 +1,388 @@
+package jsonrpc_client
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/INFURA/go-libs/crypto"
+	"github.com/INFURA/go-libs/rlp"
+)
+
+// accessTupleRLP, authorizationRLP and the transaction RLP types are the
+// encoded forms of each transaction type, used for decoding
+type accessTupleRLP struct {
+	Address     [20]byte
+	StorageKeys [][32]byte
+}
+
+type authorizationRLP struct {
+	ChainId *big.Int
+	Address [20]byte
+	Nonce   uint64
+	YParity uint64
+	R       *big.Int
+	S       *big.Int
+}
+
+type legacyTxRLP struct {
+	Nonce    uint64
+	GasPrice *big.Int
+	Gas      uint64
+	To       []byte
+	Value    *big.Int
+	Data     []byte
+	V        *big.Int
+	R        *big.Int
+	S        *big.Int
+}
+
+type accessListTxRLP struct {
+	ChainId    *big.Int
+	Nonce      uint64
+	GasPrice   *big.Int
+	Gas        uint64
+	To         []byte
+	Value      *big.Int
+	Data       []byte
+	AccessList []accessTupleRLP
+	YParity    uint64
+	R          *big.Int
+	S          *big.Int
+}
+
+type dynamicFeeTxRLP struct {
+	ChainId              *big.Int
+	Nonce                uint64
+	MaxPriorityFeePerGas *big.Int
+	MaxFeePerGas         *big.Int
+	Gas                  uint64
+	To                   []byte
+	Value                *big.Int
+	Data                 []byte
+	AccessList           []accessTupleRLP
+	YParity              uint64
+	R                    *big.Int
+	S                    *big.Int
+}
+
+type blobTxRLP struct {
+	ChainId              *big.Int
+	Nonce                uint64
+	MaxPriorityFeePerGas *big.Int
+	MaxFeePerGas         *big.Int
+	Gas                  uint64
+	To                   [20]byte
+	Value                *big.Int
+	Data                 []byte
+	AccessList           []accessTupleRLP
+	MaxFeePerBlobGas     *big.Int
+	BlobVersionedHashes  [][32]byte
+	YParity              uint64
+	R                    *big.Int
+	S                    *big.Int
+}
+
+type setCodeTxRLP struct {
+	ChainId              *big.Int
+	Nonce                uint64
+	MaxPriorityFeePerGas *big.Int
+	MaxFeePerGas         *big.Int
+	Gas                  uint64
+	To                   [20]byte
+	Value                *big.Int
+	Data                 []byte
+	AccessList           []accessTupleRLP
+	AuthorizationList    []authorizationRLP
+	YParity              uint64
+	R                    *big.Int
+	S                    *big.Int
+}
+
+// NewTransactionFromRaw creates a new Transaction from the hex encoded
+// consensus encoding of a signed transaction, such as Raw
+func NewTransactionFromRaw(raw string) (*Transaction, error) {
+	b, err := decodeHex(raw)
+	if err != nil {
+		return nil, fmt.Errorf("NewTransactionFromRaw: %v", err)
+	}
+	tx := Transaction{}
+	err = tx.UnmarshalBinary(b)
+	if err != nil {
+		return nil, err
+	}
+	return &tx, nil
+}
+
+// UnmarshalBinary decodes a signed transaction from its consensus encoding.
+// Blob transactions are also accepted in their network form, which wraps the
+// transaction with its blobs. From is left empty since it can only be
+// obtained by recovering the signer.
+func (tx *Transaction) UnmarshalBinary(b []byte) error {
+
+	if len(b) == 0 {
+		return errors.New("UnmarshalBinary: empty input")
+	}
+
+	decoded := Transaction{Raw: append(HexBytes{}, b...)}
+	var txType uint64
+	var err error
+	switch {
+	case b[0] >= 0xc0:
+		txType = LEGACY_TX_TYPE
+		err = decoded.decodeLegacy(b)
+	case b[0] < 0x80:
+		txType = uint64(b[0])
+		b, err = decoded.decodeTyped(txType, b[1:])
+	default:
+		err = fmt.Errorf("invalid transaction type byte %#x", b[0])
+	}
+	if err != nil {
+		return fmt.Errorf("UnmarshalBinary: %v", err)
+	}
+
+	decoded.Type = &txType
+	decoded.Hash = BytesToHash(crypto.Keccak256(b))
+	*tx = decoded
+	return nil
+}
+
+// decodeLegacy decodes the fields of a legacy transaction
+func (tx *Transaction) decodeLegacy(b []byte) error {
+
+	var enc legacyTxRLP
+	err := rlp.Decode(b, &enc)
+	if err != nil {
+		return err
+	}
+	to, err := decodeTo(enc.To)
+	if err != nil {
+		return err
+	}
+
+	tx.Nonce = enc.Nonce
+	tx.GasPrice = enc.GasPrice
+	tx.Gas = enc.Gas
+	tx.To = to
+	tx.Value = enc.Value
+	tx.Input = enc.Data
+	tx.V = enc.V
+	tx.R = enc.R
+	tx.S = enc.S
+
+	// EIP-155 signatures fold the chain ID into V
+	if enc.V.Cmp(big.NewInt(35)) >= 0 {
+		chainId := new(big.Int).Sub(enc.V, big.NewInt(35))
+		tx.ChainId = chainId.Rsh(chainId, 1)
+	}
+	return nil
+}
+
+// decodeTyped decodes the fields of a typed transaction from its payload,
+// returning the canonical encoding the transaction hash is computed from
+func (tx *Transaction) decodeTyped(txType uint64, payload []byte) ([]byte, error) {
+
+	var yParity uint64
+	var r, s *big.Int
+	var accessList []accessTupleRLP
+	var err error
+
+	switch txType {
+	case ACCESS_LIST_TX_TYPE:
+		var enc accessListTxRLP
+		err = rlp.Decode(payload, &enc)
+		if err != nil {
+			return nil, err
+		}
+		tx.To, err = decodeTo(enc.To)
+		tx.ChainId, tx.Nonce, tx.GasPrice, tx.Gas = enc.ChainId, enc.Nonce, enc.GasPrice, enc.Gas
+		tx.Value, tx.Input = enc.Value, enc.Data
+		accessList, yParity, r, s = enc.AccessList, enc.YParity, enc.R, enc.S
+
+	case DYNAMIC_FEE_TX_TYPE:
+		var enc dynamicFeeTxRLP
+		err = rlp.Decode(payload, &enc)
+		if err != nil {
+			return nil, err
+		}
+		tx.To, err = decodeTo(enc.To)
+		tx.ChainId, tx.Nonce, tx.Gas = enc.ChainId, enc.Nonce, enc.Gas
+		tx.MaxPriorityFeePerGas, tx.MaxFeePerGas = enc.MaxPriorityFeePerGas, enc.MaxFeePerGas
+		tx.Value, tx.Input = enc.Value, enc.Data
+		accessList, yParity, r, s = enc.AccessList, enc.YParity, enc.R, enc.S
+
+	case BLOB_TX_TYPE:
+		// the network form is [transaction, blobs, commitments, proofs]
+		content, _, err := rlp.SplitList(payload)
+		if err != nil {
+			return nil, err
+		}
+		kind, _, rest, err := rlp.Split(content)
+		if err == nil && kind == rlp.List {
+			payload = content[:len(content)-len(rest)]
+		}
+
+		var enc blobTxRLP
+		err = rlp.Decode(payload, &enc)
+		if err != nil {
+			return nil, err
+		}
+		to := Address(enc.To)
+		tx.To = &to
+		tx.ChainId, tx.Nonce, tx.Gas = enc.ChainId, enc.Nonce, enc.Gas
+		tx.MaxPriorityFeePerGas, tx.MaxFeePerGas = enc.MaxPriorityFeePerGas, enc.MaxFeePerGas
+		tx.Value, tx.Input = enc.Value, enc.Data
+		tx.MaxFeePerBlobGas = enc.MaxFeePerBlobGas
+		tx.BlobVersionedHashes = make([]Hash, len(enc.BlobVersionedHashes))
+		for i, hash := range enc.BlobVersionedHashes {
+			tx.BlobVersionedHashes[i] = Hash(hash)
+		}
+		accessList, yParity, r, s = enc.AccessList, enc.YParity, enc.R, enc.S
+
+	case SET_CODE_TX_TYPE:
+		var enc setCodeTxRLP
+		err = rlp.Decode(payload, &enc)
+		if err != nil {
+			return nil, err
+		}
+		to := Address(enc.To)
+		tx.To = &to
+		tx.ChainId, tx.Nonce, tx.Gas = enc.ChainId, enc.Nonce, enc.Gas
+		tx.MaxPriorityFeePerGas, tx.MaxFeePerGas = enc.MaxPriorityFeePerGas, enc.MaxFeePerGas
+		tx.Value, tx.Input = enc.Value, enc.Data
+		tx.AuthorizationList = make([]Authorization, len(enc.AuthorizationList))
+		for i, auth := range enc.AuthorizationList {
+			tx.AuthorizationList[i] = Authorization{
+				ChainId: auth.ChainId,
+				Address: Address(auth.Address),
+				Nonce:   auth.Nonce,
+				YParity: auth.YParity,
+				R:       auth.R,
+				S:       auth.S,
+			}
+		}
+		accessList, yParity, r, s = enc.AccessList, enc.YParity, enc.R, enc.S
+
+	default:
+		return nil, fmt.Errorf("unsupported transaction type %d", txType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tx.AccessList = make(AccessList, len(accessList))
+	for i, tuple := range accessList {
+		tx.AccessList[i] = AccessTuple{
+			Address:     Address(tuple.Address),
+			StorageKeys: make([]Hash, len(tuple.StorageKeys)),
+		}
+		for j, key := range tuple.StorageKeys {
+			tx.AccessList[i].StorageKeys[j] = Hash(key)
+		}
+	}
+
+	// typed transactions report their signature parity as V too
+	tx.YParity = &yParity
+	tx.V = new(big.Int).SetUint64(yParity)
+	tx.R = r
+	tx.S = s
+
+	return append([]byte{byte(txType)}, payload...), nil
+}
+
+// decodeTo decodes a recipient, which is empty for contract creations
+func decodeTo(b []byte) (*Address, error) {
+	switch len(b) {
+	case 0:
+		return nil, nil
+	case ADDRESS_LENGTH:
+		to := BytesToAddress(b)
+		return &to, nil
+	}
+	return nil, fmt.Errorf("To: got %d bytes, want %d", len(b), ADDRESS_LENGTH)
+}
+
+// txType returns the EIP-2718 type of the transaction, legacy if unset
+func (tx *Transaction) txType() uint64 {
+	if tx.Type == nil {
+		return LEGACY_TX_TYPE
+	}
+	return *tx.Type
+}
+
+// MarshalBinary returns the consensus encoding of a signed transaction: the
+// RLP list of its fields for legacy transactions and the type byte followed
+// by the RLP list for typed (EIP-2718) transactions. It is the encoding
+// committed to by transactionsRoot and accepted by eth_sendRawTransaction.
+func (tx *Transaction) MarshalBinary() ([]byte, error) {
+
+	txType := tx.txType()
+	payload, err := tx.payload(txType)
+	if err != nil {
+		return nil, fmt.Errorf("MarshalBinary %v", err)
+	}
+	if txType == LEGACY_TX_TYPE {
+		payload = append(payload, tx.V)
+	} else {
+		payload = append(payload, tx.yParity())
+	}
+	payload = append(payload, tx.R, tx.S)
+
+	enc, err := rlp.EncodeToBytes(payload)
+	if err != nil {
+		return nil, fmt.Errorf("MarshalBinary %v", err)
+	}
+	if txType == LEGACY_TX_TYPE {
+		return enc, nil
+	}
+	return append([]byte{byte(txType)}, enc...), nil
+}
+
+// yParity returns the signature parity of a typed transaction, which nodes
+// also report as V
+func (tx *Transaction) yParity() uint64 {
+	if tx.YParity != nil {
+		return *tx.YParity
+	}
+	if tx.V != nil {
+		return tx.V.Uint64()
+	}
+	return 0
+}
+
+// payload returns the fields of a transaction of the given type that precede
+// its signature, in encoding order
+func (tx *Transaction) payload(txType uint64) ([]interface{}, error) {
+
+	// the recipient is empty for contract creations
+	var to []byte
+	if tx.To != nil {
+		to = tx.To.Bytes()
+	}
+
+	switch txType {
+	case LEGACY_TX_TYPE:
+		return []interface{}{tx.Nonce, tx.GasPrice, tx.Gas, to, tx.Value, tx.Input}, nil
+
+	case ACCESS_LIST_TX_TYPE:
+		return []interface{}{tx.ChainId, tx.Nonce, tx.GasPrice, tx.Gas, to, tx.Value, tx.Input, tx.AccessList}, nil
+
+	case DYNAMIC_FEE_TX_TYPE, BLOB_TX_TYPE, SET_CODE_TX_TYPE:
+		if txType != DYNAMIC_FEE_TX_TYPE && tx.To == nil {
+			// blob and set code transactions cannot create contracts
+			return nil, fmt.Errorf("To: required for type %d transactions", txType)
+		}
+		payload := []interface{}{
+			tx.ChainId, tx.Nonce, tx.MaxPriorityFeePerGas, tx.MaxFeePerGas, tx.Gas,
+			to, tx.Value, tx.Input, tx.AccessList,
+		}
+		switch txType {
+		case BLOB_TX_TYPE:
+			payload = append(payload, tx.MaxFeePerBlobGas, tx.BlobVersionedHashes)
+		case SET_CODE_TX_TYPE:
+			payload = append(payload, tx.AuthorizationList)
+		}
+		return payload, nil
+	}
+	return nil, fmt.Errorf("Type: unsupported transaction type %d", txType)
+}
@@ -0,0 +1,138 @@
+package jsonrpc_client
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/INFURA/go-libs/crypto"
+)
+
+func TestTransactionMarshalFrontier(t *testing.T) {
+
+	tx := mustDecodeTransaction(t, frontierTransaction)
+	b, err := tx.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash := BytesToHash(crypto.Keccak256(b)); hash != tx.Hash {
+		t.Errorf("keccak(MarshalBinary()) = %v, want %v", hash, tx.Hash)
+	}
+
+	decoded, err := NewTransactionFromRaw("0x" + hex.EncodeToString(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Hash != tx.Hash || *decoded.To != *tx.To || decoded.Value.Cmp(tx.Value) != 0 || decoded.S.Cmp(tx.S) != 0 {
+		t.Errorf("NewTransactionFromRaw() = %+v, want %+v", decoded, tx)
+	}
+}
+
+// TestTransactionEIP155 checks the example of EIP-155
+func TestTransactionEIP155(t *testing.T) {
+
+	key, err := crypto.HexToPrivateKey("4646464646464646464646464646464646464646464646464646464646464646")
+	if err != nil {
+		t.Fatal(err)
+	}
+	to := BytesToAddress(bytes.Repeat([]byte{0x35}, ADDRESS_LENGTH))
+	value, _ := new(big.Int).SetString("1000000000000000000", 10)
+	tx := Transaction{Nonce: 9, GasPrice: big.NewInt(20000000000), Gas: 21000, To: &to, Value: value, ChainId: big.NewInt(1)}
+
+	hash, err := tx.SigningHash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "daf5a779ae972f972197303d7b574746c7ef83eadac0f2791ad23db92e4c8e53"; hex.EncodeToString(hash) != want {
+		t.Errorf("SigningHash() = %x, want %s", hash, want)
+	}
+
+	raw, err := NewSigner(big.NewInt(1)).SignTransaction(&tx, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "f86c098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a76400008025a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276a067cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83"
+	if hex.EncodeToString(raw) != want {
+		t.Errorf("SignTransaction() = %x, want %s", raw, want)
+	}
+	if tx.V.Int64() != 37 {
+		t.Errorf("V = %v, want 37", tx.V)
+	}
+}
+
+func TestTransactionMarshalTypes(t *testing.T) {
+
+	to := BytesToAddress([]byte{0x35})
+	auth := Authorization{ChainId: big.NewInt(1), Address: to, Nonce: 7, YParity: 1, R: big.NewInt(5), S: big.NewInt(6)}
+	tests := []struct {
+		tx   Transaction
+		want string
+	}{
+		{
+			Transaction{Nonce: 1, GasPrice: big.NewInt(1), Gas: 21000, Value: big.NewInt(0), Input: HexBytes{1, 2},
+				ChainId: big.NewInt(5), V: big.NewInt(45), R: big.NewInt(2), S: big.NewInt(3)},
+			"cd010182520880808201022d0203",
+		},
+		{
+			Transaction{Type: newUint64(ACCESS_LIST_TX_TYPE), ChainId: big.NewInt(1), Nonce: 2, GasPrice: big.NewInt(1), Gas: 21000, To: &to, Value: big.NewInt(0),
+				AccessList: AccessList{{Address: to, StorageKeys: []Hash{{1}, {2}}}}, YParity: newUint64(1), R: big.NewInt(2), S: big.NewInt(3)},
+			"01f87d0102018252089400000000000000000000000000000000000000358080f85bf859940000000000000000000000000000000000000035f842a00100000000000000000000000000000000000000000000000000000000000000a00200000000000000000000000000000000000000000000000000000000000000010203",
+		},
+		{
+			Transaction{Type: newUint64(DYNAMIC_FEE_TX_TYPE), ChainId: big.NewInt(1), Nonce: 3, MaxFeePerGas: big.NewInt(2), MaxPriorityFeePerGas: big.NewInt(1),
+				Gas: 21000, Value: big.NewInt(0), V: big.NewInt(1), R: big.NewInt(2), S: big.NewInt(3)},
+			"02ce01030102825208808080c0010203",
+		},
+		{
+			Transaction{Type: newUint64(BLOB_TX_TYPE), ChainId: big.NewInt(1), Nonce: 4, MaxFeePerGas: big.NewInt(2), MaxPriorityFeePerGas: big.NewInt(1), Gas: 21000, To: &to,
+				Value: big.NewInt(0), MaxFeePerBlobGas: big.NewInt(1), BlobVersionedHashes: []Hash{{1}}, R: big.NewInt(2), S: big.NewInt(3)},
+			"03f845010401028252089400000000000000000000000000000000000000358080c001e1a00100000000000000000000000000000000000000000000000000000000000000800203",
+		},
+		{
+			Transaction{Type: newUint64(SET_CODE_TX_TYPE), ChainId: big.NewInt(1), Nonce: 5, MaxFeePerGas: big.NewInt(2), MaxPriorityFeePerGas: big.NewInt(1), Gas: 21000, To: &to,
+				Value: big.NewInt(0), AuthorizationList: []Authorization{auth}, R: big.NewInt(2), S: big.NewInt(3)},
+			"04f83e010501028252089400000000000000000000000000000000000000358080c0dbda0194000000000000000000000000000000000000003507010506800203",
+		},
+	}
+	for _, test := range tests {
+		b, err := test.tx.MarshalBinary()
+		if err != nil {
+			t.Errorf("type %d: %v", test.tx.txType(), err)
+			continue
+		}
+		if hex.EncodeToString(b) != test.want {
+			t.Errorf("type %d: MarshalBinary() = %x, want %s", test.tx.txType(), b, test.want)
+		}
+
+		// decoding and encoding again gives the same bytes
+		var decoded Transaction
+		if err := decoded.UnmarshalBinary(b); err != nil {
+			t.Errorf("type %d: UnmarshalBinary(): %v", test.tx.txType(), err)
+			continue
+		}
+		b2, err := decoded.MarshalBinary()
+		if err != nil || !bytes.Equal(b, b2) {
+			t.Errorf("type %d: round trip gave %x, %v", test.tx.txType(), b2, err)
+		}
+	}
+}
+
+func TestTransactionMarshalErrors(t *testing.T) {
+	tests := []struct {
+		tx   Transaction
+		want string
+	}{
+		{Transaction{Type: newUint64(BLOB_TX_TYPE), ChainId: big.NewInt(1)}, "To: required for type 3 transactions"},
+		{Transaction{Type: newUint64(SET_CODE_TX_TYPE), ChainId: big.NewInt(1)}, "To: required for type 4 transactions"},
+		{Transaction{Type: newUint64(5)}, "unsupported transaction type 5"},
+		{Transaction{Value: big.NewInt(-1)}, "negative big.Int"},
+	}
+	for _, test := range tests {
+		_, err := test.tx.MarshalBinary()
+		if err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("MarshalBinary() = %v, want %q", err, test.want)
+		}
+	}
+}
@@ -0,0 +1,264 @@
+package jsonrpc_client
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+// frontierTransaction is the first mainnet transaction, in block 46147, in
+// the JSON-RPC form
+const frontierTransaction = `{
+	"blockHash": "0x4e3a3754410177e6937ef1f84bba68ea139e8d1a2258c5f85db9f1cd715a1bdd",
+	"blockNumber": "0xb443",
+	"from": "0xa1e4380a3b1f749673e270229993ee55f35663b4",
+	"gas": "0x5208",
+	"gasPrice": "0x2d79883d2000",
+	"hash": "0x5c504ed432cb51138bcf09aa5e8a410dd4a1e204ef84bfed1be16dfba1b22060",
+	"input": "0x",
+	"nonce": "0x0",
+	"r": "0x88ff6cf0fefd94db46111149ae4bfc179e9b94721fffd821d38d16464b3f71d0",
+	"s": "0x45e0aff800961cfce805daef7016b9b675c137a6a41a548f7b60a3484c06a33a",
+	"to": "0x5df9b87991262f6ba471f09758cde1c0fc1de734",
+	"transactionIndex": "0x0",
+	"type": "0x0",
+	"v": "0x1c",
+	"value": "0x7a69"
+}`
+
+// mustDecodeTransaction decodes a transaction in the JSON-RPC form
+func mustDecodeTransaction(t *testing.T, s string) *Transaction {
+	t.Helper()
+	var tx Transaction
+	err := json.Unmarshal([]byte(s), &tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &tx
+}
+
+func TestTransactionSignatureValues(t *testing.T) {
+
+	tx := mustDecodeTransaction(t, frontierTransaction)
+	r, _ := new(big.Int).SetString("88ff6cf0fefd94db46111149ae4bfc179e9b94721fffd821d38d16464b3f71d0", 16)
+	if tx.R.Cmp(r) != 0 {
+		t.Errorf("R = %x, want %x", tx.R, r)
+	}
+
+	// the JSON-RPC and stored forms keep R and S as hex quantities
+	b, err := json.Marshal(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), `"r":"0x88ff6cf0`) {
+		t.Errorf("R not encoded as a hex quantity: %s", b)
+	}
+	b, err = tx.ToJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), `"s":"0x45e0aff8`) {
+		t.Errorf("S not stored as a hex quantity: %s", b)
+	}
+	stored, err := NewTransactionFromJSON(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tx.Equals(stored) {
+		t.Errorf("transaction changed in a stored form round trip: %s", b)
+	}
+
+	// values are compared as numbers
+	tx2 := mustDecodeTransaction(t, frontierTransaction)
+	tx2.S = new(big.Int).Add(tx.S, big.NewInt(1))
+	if tx.Equals(tx2) {
+		t.Errorf("transactions with different S are equal")
+	}
+	tx2.S = new(big.Int).Set(tx.S)
+	if !tx.Equals(tx2) {
+		t.Errorf("transactions with the same S are not equal")
+	}
+
+	// R must be a hex quantity
+	for _, invalid := range []string{`"0x01"`, `"0x"`, `"1"`, `"0x1_0"`, `"0b1"`, `27`} {
+		s := strings.Replace(frontierTransaction, `"0x88ff6cf0fefd94db46111149ae4bfc179e9b94721fffd821d38d16464b3f71d0"`, invalid, 1)
+		var tx Transaction
+		if err := json.Unmarshal([]byte(s), &tx); err == nil {
+			t.Errorf("R %s accepted", invalid)
+		}
+	}
+}
+
+func TestAuthorizationJSON(t *testing.T) {
+
+	const authJSON = `{"chainId":"0x1","address":"0x5df9b87991262f6ba471f09758cde1c0fc1de734","nonce":"0x2","yParity":"0x1","r":"0x1b","s":"0xff"}`
+	var auth Authorization
+	err := json.Unmarshal([]byte(authJSON), &auth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if auth.R.Cmp(big.NewInt(0x1b)) != 0 || auth.S.Cmp(big.NewInt(0xff)) != 0 {
+		t.Errorf("R = %v, S = %v", auth.R, auth.S)
+	}
+	b, err := json.Marshal(auth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != authJSON {
+		t.Errorf("Marshal = %s, want %s", b, authJSON)
+	}
+
+	auth2 := auth
+	auth2.R = big.NewInt(0x1b)
+	if !AreEqualAuthorizations([]Authorization{auth}, []Authorization{auth2}) {
+		t.Errorf("authorizations with the same R are not equal")
+	}
+	err = json.Unmarshal([]byte(strings.Replace(authJSON, `"0x1b"`, `"0x01b"`, 1)), &auth)
+	if err == nil {
+		t.Errorf("R with leading zeros accepted")
+	}
+}
+
+// setCodeTransaction is a pending EIP-7702 transaction with an access list,
+// in the JSON-RPC form
+const setCodeTransaction = `{
+	"blockHash": null,
+	"blockNumber": null,
+	"chainId": "0x1",
+	"from": "0x0000000000000000000000000000000000000001",
+	"gas": "0x186a0",
+	"gasPrice": "0x3b9aca00",
+	"hash": "0x00000000000000000000000000000000000000000000000000000000000000cd",
+	"input": "0x",
+	"maxFeePerGas": "0x77359400",
+	"maxPriorityFeePerGas": "0x3b9aca00",
+	"nonce": "0x5",
+	"to": "0x0000000000000000000000000000000000000035",
+	"transactionIndex": null,
+	"type": "0x4",
+	"value": "0x0",
+	"accessList": [{
+		"address": "0x0000000000000000000000000000000000000035",
+		"storageKeys": ["0x0000000000000000000000000000000000000000000000000000000000000001"]
+	}],
+	"authorizationList": [{
+		"chainId": "0x1",
+		"address": "0x5df9b87991262f6ba471f09758cde1c0fc1de734",
+		"nonce": "0x2",
+		"yParity": "0x1",
+		"r": "0x1b",
+		"s": "0xff"
+	}],
+	"v": "0x1",
+	"yParity": "0x1",
+	"r": "0x2",
+	"s": "0x3"
+}`
+
+// blobTransaction is an EIP-4844 transaction in the JSON-RPC form
+const blobTransaction = `{
+	"blockHash": "0x00000000000000000000000000000000000000000000000000000000000000ab",
+	"blockNumber": "0x13a5a90",
+	"chainId": "0x1",
+	"from": "0x0000000000000000000000000000000000000001",
+	"gas": "0x5208",
+	"gasPrice": "0x3b9aca00",
+	"hash": "0x00000000000000000000000000000000000000000000000000000000000000ce",
+	"input": "0x",
+	"maxFeePerGas": "0x77359400",
+	"maxPriorityFeePerGas": "0x3b9aca00",
+	"maxFeePerBlobGas": "0x1",
+	"blobVersionedHashes": [
+		"0x0100000000000000000000000000000000000000000000000000000000000001",
+		"0x0100000000000000000000000000000000000000000000000000000000000002"
+	],
+	"nonce": "0x6",
+	"to": "0x0000000000000000000000000000000000000035",
+	"transactionIndex": "0x4",
+	"type": "0x3",
+	"value": "0x0",
+	"accessList": [],
+	"v": "0x0",
+	"yParity": "0x0",
+	"r": "0x2",
+	"s": "0x3"
+}`
+
+func TestTransactionTypedFields(t *testing.T) {
+
+	tx := mustDecodeTransaction(t, setCodeTransaction)
+	to := BytesToAddress([]byte{0x35})
+	if tx.Type == nil || *tx.Type != SET_CODE_TX_TYPE || tx.YParity == nil || *tx.YParity != 1 ||
+		tx.MaxFeePerGas.Cmp(big.NewInt(2000000000)) != 0 || tx.MaxPriorityFeePerGas.Cmp(big.NewInt(1000000000)) != 0 {
+		t.Errorf("decoded the wrong type or fee fields: %+v", tx)
+	}
+	if len(tx.AccessList) != 1 || tx.AccessList[0].Address != to || tx.AccessList[0].StorageKeys[0] != (Hash{31: 1}) {
+		t.Errorf("decoded the wrong access list: %+v", tx.AccessList)
+	}
+	if len(tx.AuthorizationList) != 1 || tx.AuthorizationList[0].Nonce != 2 || tx.AuthorizationList[0].YParity != 1 {
+		t.Errorf("decoded the wrong authorization list: %+v", tx.AuthorizationList)
+	}
+	if tx.BlockHash != nil || tx.BlockNumber != nil || tx.TransactionIndex != nil {
+		t.Errorf("pending transaction decoded with a block")
+	}
+
+	blobTx := mustDecodeTransaction(t, blobTransaction)
+	if blobTx.MaxFeePerBlobGas.Cmp(big.NewInt(1)) != 0 || len(blobTx.BlobVersionedHashes) != 2 ||
+		blobTx.BlobVersionedHashes[1] != (Hash{0: 1, 31: 2}) {
+		t.Errorf("decoded the wrong blob fields: %+v", blobTx)
+	}
+	// an empty access list is kept apart from a missing one
+	if blobTx.AccessList == nil || len(blobTx.AccessList) != 0 {
+		t.Errorf("AccessList = %#v, want empty", blobTx.AccessList)
+	}
+
+	for _, tx := range []*Transaction{tx, blobTx, mustDecodeTransaction(t, frontierTransaction)} {
+		b, err := json.Marshal(tx)
+		if err != nil {
+			t.Fatalf("Marshal(): %v", err)
+		}
+		if decoded := mustDecodeTransaction(t, string(b)); !tx.Equals(decoded) {
+			t.Errorf("JSON-RPC round trip changed the transaction: %s", b)
+		}
+
+		b, err = tx.ToJSON()
+		if err != nil {
+			t.Fatalf("ToJSON(): %v", err)
+		}
+		stored, err := NewTransactionFromJSON(b)
+		if err != nil || !tx.Equals(stored) {
+			t.Errorf("stored round trip changed the transaction: %s, %v", b, err)
+		}
+	}
+
+	// legacy transactions have none of the typed fields
+	legacy := mustDecodeTransaction(t, frontierTransaction)
+	if legacy.AccessList != nil || legacy.MaxFeePerGas != nil || legacy.YParity != nil || legacy.AuthorizationList != nil {
+		t.Errorf("legacy transaction decoded with typed fields: %+v", legacy)
+	}
+}
+
+func TestTransactionEqualsTypedFields(t *testing.T) {
+
+	tx := mustDecodeTransaction(t, setCodeTransaction)
+	changes := []func(*Transaction){
+		func(tx *Transaction) { tx.Type = newUint64(DYNAMIC_FEE_TX_TYPE) },
+		func(tx *Transaction) { tx.MaxFeePerGas = big.NewInt(1) },
+		func(tx *Transaction) { tx.MaxPriorityFeePerGas = nil },
+		func(tx *Transaction) { tx.YParity = newUint64(0) },
+		func(tx *Transaction) { tx.AccessList = nil },
+		func(tx *Transaction) { tx.AccessList[0].StorageKeys = nil },
+		func(tx *Transaction) { tx.AuthorizationList[0].Nonce++ },
+		func(tx *Transaction) { tx.AuthorizationList = nil },
+		func(tx *Transaction) { tx.MaxFeePerBlobGas = big.NewInt(1) },
+		func(tx *Transaction) { tx.BlobVersionedHashes = []Hash{{1}} },
+	}
+	for i, change := range changes {
+		changed := mustDecodeTransaction(t, setCodeTransaction)
+		change(changed)
+		if tx.Equals(changed) || changed.Equals(tx) {
+			t.Errorf("%d: Equals() ignored a change", i)
+		}
+	}
+}
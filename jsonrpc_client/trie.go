@@ -0,0 +1,121 @@
+package jsonrpc_client
+
+import (
+	"github.com/INFURA/go-libs/crypto"
+	"github.com/INFURA/go-libs/rlp"
+)
+
+// trieEntry is a key, as nibbles, and its value
+type trieEntry struct {
+	key   []byte
+	value []byte
+}
+
+// deriveRoot computes the root of the Merkle-Patricia trie mapping the RLP
+// encoded index of each item to the item, as committed to by the
+// transactionsRoot, receiptsRoot and withdrawalsRoot of a block header
+func deriveRoot(items [][]byte) []byte {
+	keys := make([][]byte, len(items))
+	for i := range items {
+		keys[i] = rlp.EncodeUint(uint64(i))
+	}
+	return trieRoot(keys, items)
+}
+
+// trieRoot computes the root hash of the Merkle-Patricia trie holding the
+// given keys and values. Keys must be unique.
+func trieRoot(keys, values [][]byte) []byte {
+	entries := make([]trieEntry, len(keys))
+	for i, key := range keys {
+		nibbles := make([]byte, 0, 2*len(key))
+		for _, b := range key {
+			nibbles = append(nibbles, b>>4, b&0x0f)
+		}
+		entries[i] = trieEntry{key: nibbles, value: values[i]}
+	}
+	// the root is hashed even when its encoding is shorter than a hash
+	return crypto.Keccak256(trieNode(entries, 0))
+}
+
+// trieNode returns the encoding of the node holding entries, whose keys share
+// their first depth nibbles
+func trieNode(entries []trieEntry, depth int) []byte {
+
+	switch len(entries) {
+	case 0:
+		return rlp.EmptyString
+	case 1:
+		return rlp.EncodeList(
+			rlp.EncodeBytes(compactKey(entries[0].key[depth:], true)),
+			rlp.EncodeBytes(entries[0].value),
+		)
+	}
+
+	// an extension node holds the remaining prefix shared by all keys
+	prefix := len(entries[0].key) - depth
+	for _, entry := range entries[1:] {
+		n := 0
+		for n < prefix && depth+n < len(entry.key) && entry.key[depth+n] == entries[0].key[depth+n] {
+			n++
+		}
+		prefix = n
+	}
+	if prefix > 0 {
+		return rlp.EncodeList(
+			rlp.EncodeBytes(compactKey(entries[0].key[depth:depth+prefix], false)),
+			trieRef(trieNode(entries, depth+prefix)),
+		)
+	}
+
+	// a branch node has a child per next nibble and the value of the key
+	// ending here, if any
+	var children [16][]trieEntry
+	value := []byte{}
+	for _, entry := range entries {
+		if len(entry.key) == depth {
+			value = entry.value
+			continue
+		}
+		nibble := entry.key[depth]
+		children[nibble] = append(children[nibble], entry)
+	}
+	items := make([][]byte, 0, 17)
+	for _, child := range children {
+		if len(child) == 0 {
+			items = append(items, rlp.EmptyString)
+			continue
+		}
+		items = append(items, trieRef(trieNode(child, depth+1)))
+	}
+	items = append(items, rlp.EncodeBytes(value))
+	return rlp.EncodeList(items...)
+}
+
+// trieRef returns how a parent refers to a child node: nodes shorter than a
+// hash are embedded, others are referenced by their hash
+func trieRef(node []byte) []byte {
+	if len(node) < 32 {
+		return node
+	}
+	return rlp.EncodeBytes(crypto.Keccak256(node))
+}
+
+// compactKey applies the hex-prefix encoding to a nibble path, flagging
+// whether it terminates at a leaf
+func compactKey(nibbles []byte, leaf bool) []byte {
+	var flag byte
+	if leaf {
+		flag = 2
+	}
+	if len(nibbles)%2 == 1 {
+		flag++
+		nibbles = append([]byte{flag}, nibbles...)
+	} else {
+		nibbles = append([]byte{flag, 0}, nibbles...)
+	}
+	key := make([]byte, len(nibbles)/2)
+	for i := range key {
+		key[i] = nibbles[2*i]<<4 | nibbles[2*i+1]
+	}
+	return key
+}
@@ -0,0 +1,36 @@
+package jsonrpc_client
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+// TestTrieRoot checks vectors of the ethereum/tests trieanyorder suite
+func TestTrieRoot(t *testing.T) {
+	tests := []struct {
+		name string
+		kv   [][2]string
+		want string
+	}{
+		{"dogs", [][2]string{{"doe", "reindeer"}, {"dog", "puppy"}, {"dogglesworth", "cat"}}, "8aad789dff2f538bca5d8ea56e8abe10f4c7ba3a5dea95fea4cd6e7c3a1168d3"},
+		{"puppy", [][2]string{{"do", "verb"}, {"horse", "stallion"}, {"doge", "coin"}, {"dog", "puppy"}}, "5991bb8c6514148a29db676a14ac506cd2cd5775ace63c30a4fe457715e9ac84"},
+		{"foo", [][2]string{{"foo", "bar"}, {"food", "bass"}}, "17beaa1648bafa633cda809c90c04af50fc8aed3cb40d16efbddee6fdf63c4c3"},
+		{"smallValues", [][2]string{{"be", "e"}, {"dog", "puppy"}, {"bed", "d"}}, "3f67c7a47520f79faa29255d2d3c084a7a6df0453116ed7232ff10277a8be68b"},
+		{"testy", [][2]string{{"test", "test"}, {"te", "testy"}}, "8452568af70d8d140f58d941338542f645fcca50094b20f3c3d8c3df49337928"},
+		{"hex", [][2]string{{"\x00\x45", "\x01\x23\x45\x67\x89"}, {"\x45\x00", "\x98\x76\x54\x32\x10"}}, "285505fcabe84badc8aa310e2aae17eddc7d120aabec8a476902c8184b3a3503"},
+	}
+	for _, test := range tests {
+		var keys, values [][]byte
+		for _, kv := range test.kv {
+			keys = append(keys, []byte(kv[0]))
+			values = append(values, []byte(kv[1]))
+		}
+		if got := hex.EncodeToString(trieRoot(keys, values)); got != test.want {
+			t.Errorf("%s: trieRoot() = %s, want %s", test.name, got, test.want)
+		}
+	}
+
+	if got := "0x" + hex.EncodeToString(deriveRoot(nil)); got != emptyRoot {
+		t.Errorf("deriveRoot(nil) = %s, want %s", got, emptyRoot)
+	}
+}
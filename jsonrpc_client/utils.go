@@ -1,6 +1,8 @@
 package jsonrpc_client
 
 import (
+	"encoding/hex"
+	"fmt"
 	"math/big"
 	"strings"
 )
@@ -23,6 +25,28 @@ func AreEqualInt(a, b *int) bool {
 	return *a == *b
 }
 
+// AreEqualUint64
+func AreEqualUint64(a, b *uint64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	return *a == *b
+}
+
+// AreEqualStringSlice
+func AreEqualStringSlice(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 // AreEqualBigInt
 func AreEqualBigInt(a, b *big.Int) bool {
 	if a == nil || b == nil {
@@ -40,3 +64,56 @@ func ZeroPad(s string, count int) string {
 	diff := count - len(s)
 	return strings.Repeat("0", diff) + s
 }
+
+// decodeHex decodes a 0x-prefixed hex string
+func decodeHex(s string) ([]byte, error) {
+	if !strings.HasPrefix(s, "0x") && !strings.HasPrefix(s, "0X") {
+		return nil, fmt.Errorf("hex string %q without 0x prefix", s)
+	}
+	return hex.DecodeString(s[2:])
+}
+
+// newUint64 returns a pointer to a copy of i
+func newUint64(i uint64) *uint64 {
+	return &i
+}
+
+// AreEqualHash
+func AreEqualHash(a, b *Hash) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	return *a == *b
+}
+
+// AreEqualAddress
+func AreEqualAddress(a, b *Address) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	return *a == *b
+}
+
+// AreEqualHashSlice
+func AreEqualHashSlice(a, b []Hash) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// AreEqualHexBytes distinguishes nil, absent values from empty ones
+func AreEqualHexBytes(a, b HexBytes) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	return a.Equal(b)
+}
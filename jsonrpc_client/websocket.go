@@ -0,0 +1,296 @@
+package jsonrpc_client
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// WebSocket opcodes (RFC 6455 section 5.2)
+const (
+	wsContinuation = 0x0
+	wsText         = 0x1
+	wsBinary       = 0x2
+	wsClose        = 0x8
+	wsPing         = 0x9
+	wsPong         = 0xa
+)
+
+// wsAcceptGUID is appended to the handshake key (RFC 6455 section 1.3)
+const wsAcceptGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// errWebSocketClosed is returned when the peer closes the connection
+var errWebSocketClosed = errors.New("websocket: connection closed")
+
+// wsConn is a minimal client side WebSocket connection carrying text messages
+type wsConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+
+	writeMu sync.Mutex
+}
+
+// dialWebSocket opens a WebSocket connection to a ws:// or wss:// URL.
+// wss:// connections use tlsConfig, or the default configuration if nil.
+func dialWebSocket(ctx context.Context, rawURL string, header http.Header, tlsConfig *tls.Config) (*wsConn, error) {
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var httpScheme, defaultPort string
+	switch u.Scheme {
+	case "ws":
+		httpScheme, defaultPort = "http", "80"
+	case "wss":
+		httpScheme, defaultPort = "https", "443"
+	default:
+		return nil, fmt.Errorf("websocket: unsupported scheme %q", u.Scheme)
+	}
+
+	addr := u.Host
+	if u.Port() == "" {
+		addr = net.JoinHostPort(u.Hostname(), defaultPort)
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	// the handshake is bound by ctx, the connection itself is not
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	if u.Scheme == "wss" {
+		config := &tls.Config{}
+		if tlsConfig != nil {
+			config = tlsConfig.Clone()
+		}
+		if config.ServerName == "" {
+			config.ServerName = u.Hostname()
+		}
+		tlsConn := tls.Client(conn, config)
+		err = tlsConn.Handshake()
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+		conn = tlsConn
+	}
+
+	ws, err := handshakeWebSocket(conn, httpScheme, u, header)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	conn.SetDeadline(time.Time{})
+	return ws, nil
+}
+
+// handshakeWebSocket upgrades conn to the WebSocket protocol
+func handshakeWebSocket(conn net.Conn, httpScheme string, u *url.URL, header http.Header) (*wsConn, error) {
+
+	nonce := make([]byte, 16)
+	_, err := rand.Read(nonce)
+	if err != nil {
+		return nil, err
+	}
+	key := base64.StdEncoding.EncodeToString(nonce)
+
+	httpURL := *u
+	httpURL.Scheme = httpScheme
+	req, err := http.NewRequest(http.MethodGet, httpURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, values := range header {
+		for _, value := range values {
+			req.Header.Add(k, value)
+		}
+	}
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Sec-WebSocket-Key", key)
+	req.Header.Set("Sec-WebSocket-Version", "13")
+
+	err = req.Write(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		return nil, fmt.Errorf("websocket: handshake failed with status %s", resp.Status)
+	}
+	if resp.Header.Get("Sec-WebSocket-Accept") != wsAcceptKey(key) {
+		return nil, errors.New("websocket: invalid Sec-WebSocket-Accept header")
+	}
+
+	return &wsConn{conn: conn, reader: reader}, nil
+}
+
+// wsAcceptKey computes the Sec-WebSocket-Accept value for a handshake key
+func wsAcceptKey(key string) string {
+	h := sha1.New()
+	h.Write([]byte(key + wsAcceptGUID))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// writeMessage writes a single, unfragmented frame
+func (ws *wsConn) writeMessage(opcode byte, data []byte) error {
+
+	ws.writeMu.Lock()
+	defer ws.writeMu.Unlock()
+
+	// client frames are always masked (RFC 6455 section 5.3)
+	frame := make([]byte, 0, 14+len(data))
+	frame = append(frame, 0x80|opcode)
+	switch {
+	case len(data) < 126:
+		frame = append(frame, 0x80|byte(len(data)))
+	case len(data) <= 0xffff:
+		frame = append(frame, 0x80|126, 0, 0)
+		binary.BigEndian.PutUint16(frame[2:], uint16(len(data)))
+	default:
+		frame = append(frame, 0x80|127, 0, 0, 0, 0, 0, 0, 0, 0)
+		binary.BigEndian.PutUint64(frame[2:], uint64(len(data)))
+	}
+
+	var mask [4]byte
+	_, err := rand.Read(mask[:])
+	if err != nil {
+		return err
+	}
+	frame = append(frame, mask[:]...)
+	offset := len(frame)
+	frame = append(frame, data...)
+	for i := range data {
+		frame[offset+i] ^= mask[i%4]
+	}
+
+	_, err = ws.conn.Write(frame)
+	return err
+}
+
+// readMessage reads the next data message, answering control frames on the way
+func (ws *wsConn) readMessage() ([]byte, error) {
+
+	var message []byte
+	for {
+		fin, opcode, payload, err := ws.readFrame()
+		if err != nil {
+			return nil, err
+		}
+
+		switch opcode {
+		case wsPing:
+			err = ws.writeMessage(wsPong, payload)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		case wsPong:
+			continue
+		case wsClose:
+			ws.writeMessage(wsClose, payload)
+			return nil, errWebSocketClosed
+		case wsText, wsBinary, wsContinuation:
+		default:
+			return nil, fmt.Errorf("websocket: unknown opcode %d", opcode)
+		}
+
+		if len(message)+len(payload) > WS_MAX_MESSAGE_SIZE {
+			return nil, fmt.Errorf("websocket: message exceeds %d bytes", WS_MAX_MESSAGE_SIZE)
+		}
+		message = append(message, payload...)
+		if fin {
+			return message, nil
+		}
+	}
+}
+
+// readFrame reads a single frame
+func (ws *wsConn) readFrame() (bool, byte, []byte, error) {
+
+	var head [2]byte
+	_, err := io.ReadFull(ws.reader, head[:])
+	if err != nil {
+		return false, 0, nil, err
+	}
+	fin := head[0]&0x80 != 0
+	opcode := head[0] & 0x0f
+	masked := head[1]&0x80 != 0
+
+	length := uint64(head[1] & 0x7f)
+	switch length {
+	case 126:
+		var ext [2]byte
+		_, err = io.ReadFull(ws.reader, ext[:])
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		_, err = io.ReadFull(ws.reader, ext[:])
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+	if err != nil {
+		return false, 0, nil, err
+	}
+	if length > WS_MAX_MESSAGE_SIZE {
+		return false, 0, nil, fmt.Errorf("websocket: frame exceeds %d bytes", WS_MAX_MESSAGE_SIZE)
+	}
+
+	var mask [4]byte
+	if masked {
+		_, err = io.ReadFull(ws.reader, mask[:])
+		if err != nil {
+			return false, 0, nil, err
+		}
+	}
+
+	payload := make([]byte, length)
+	_, err = io.ReadFull(ws.reader, payload)
+	if err != nil {
+		return false, 0, nil, err
+	}
+	if masked {
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+	}
+
+	return fin, opcode, payload, nil
+}
+
+// setReadDeadline bounds the next read
+func (ws *wsConn) setReadDeadline(t time.Time) error {
+	return ws.conn.SetReadDeadline(t)
+}
+
+// close sends a close frame and closes the underlying connection
+func (ws *wsConn) close() error {
+	ws.writeMessage(wsClose, []byte{0x03, 0xe8}) // 1000, normal closure
+	return ws.conn.Close()
+}
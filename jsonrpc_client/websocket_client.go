@@ -0,0 +1,428 @@
+package jsonrpc_client
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// WebSocketClient is an EthereumClient connected to a node over a WebSocket.
+// It supports every EthereumClient method as well as eth_subscribe, and
+// reconnects and resubscribes automatically when the connection drops.
+type WebSocketClient struct {
+	*EthereumClient
+
+	ws *wsTransport
+}
+
+// DialWebSocket connects to a node at a ws:// or wss:// URL. Headers set
+// through the options are sent with the opening handshake, and wss://
+// connections use the TLS configuration of the WithHTTPClient transport
+// when it is an *http.Transport.
+func DialWebSocket(ctx context.Context, url string, opts ...ClientOption) (*WebSocketClient, error) {
+
+	client := NewEthereumClient(url, opts...)
+	var tlsConfig *tls.Config
+	if transport, ok := client.getHTTPClient().Transport.(*http.Transport); ok {
+		tlsConfig = transport.TLSClientConfig
+	}
+	conn, err := dialWebSocket(ctx, url, client.header, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	ws := &wsTransport{
+		url:       url,
+		header:    client.header,
+		tlsConfig: tlsConfig,
+		conn:      conn,
+		pending:   make(map[int64]*wsPending),
+		subs:      make(map[string]*Subscription),
+		closing:   make(chan struct{}),
+	}
+	go ws.run(conn)
+
+	client.transport = ws
+	return &WebSocketClient{EthereumClient: client, ws: ws}, nil
+}
+
+// Close closes the connection, failing calls in flight and ending all
+// subscriptions with ErrClientClosed
+func (client *WebSocketClient) Close() error {
+	return client.ws.close()
+}
+
+// wsTransport multiplexes JSON-RPC calls and subscription notifications over
+// a WebSocket connection
+type wsTransport struct {
+	url       string
+	header    http.Header
+	tlsConfig *tls.Config
+
+	mu      sync.Mutex
+	conn    *wsConn // nil while reconnecting
+	pending map[int64]*wsPending
+	subs    map[string]*Subscription
+	closing chan struct{}
+	closed  bool
+}
+
+// wsPending is a call waiting for its response. A batch registers the same
+// wsPending under each of its request IDs.
+type wsPending struct {
+	ids  []int64
+	done chan wsResult
+	// onResponse runs on the read loop before any later message is
+	// dispatched, so subscriptions are registered before their first
+	// notification arrives
+	onResponse func(body []byte)
+}
+
+type wsResult struct {
+	body []byte
+	err  error
+}
+
+// roundTrip implements transport
+func (t *wsTransport) roundTrip(ctx context.Context, payload []byte, ids []int64) ([]byte, error) {
+	return t.send(ctx, payload, ids, nil)
+}
+
+// send writes payload and waits for the response to the requests with the given IDs
+func (t *wsTransport) send(ctx context.Context, payload []byte, ids []int64, onResponse func([]byte)) ([]byte, error) {
+
+	p := &wsPending{
+		ids:        ids,
+		done:       make(chan wsResult, 1),
+		onResponse: onResponse,
+	}
+
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		return nil, ErrClientClosed
+	}
+	conn := t.conn
+	if conn == nil {
+		t.mu.Unlock()
+		return nil, ErrNotConnected
+	}
+	for _, id := range ids {
+		t.pending[id] = p
+	}
+	t.mu.Unlock()
+
+	err := conn.writeMessage(wsText, payload)
+	if err != nil {
+		t.removePending(p)
+		return nil, err
+	}
+
+	select {
+	case res := <-p.done:
+		return res.body, res.err
+	case <-ctx.Done():
+		t.removePending(p)
+		return nil, ctx.Err()
+	}
+}
+
+// removePending forgets a call, returning false if it was already answered
+func (t *wsTransport) removePending(p *wsPending) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.pending[p.ids[0]] != p {
+		return false
+	}
+	for _, id := range p.ids {
+		delete(t.pending, id)
+	}
+	return true
+}
+
+// run reads from conn until it fails, then reconnects and resubscribes until
+// the transport is closed
+func (t *wsTransport) run(conn *wsConn) {
+	for {
+		err := t.read(conn)
+
+		t.mu.Lock()
+		t.conn = nil
+		pending := t.pending
+		t.pending = make(map[int64]*wsPending)
+		subs := make([]*Subscription, 0, len(t.subs))
+		for _, sub := range t.subs {
+			subs = append(subs, sub)
+		}
+		t.subs = make(map[string]*Subscription)
+		closed := t.closed
+		t.mu.Unlock()
+
+		// the responses to calls in flight are lost with the connection
+		failed := make(map[*wsPending]bool, len(pending))
+		for _, p := range pending {
+			if !failed[p] {
+				p.done <- wsResult{err: fmt.Errorf("websocket: connection lost: %v", err)}
+				failed[p] = true
+			}
+		}
+
+		if !closed {
+			conn = t.reconnect()
+		}
+		if closed || conn == nil {
+			for _, sub := range subs {
+				sub.end(ErrClientClosed)
+			}
+			return
+		}
+
+		go t.resubscribe(subs)
+	}
+}
+
+// read dispatches messages from conn until the connection fails
+func (t *wsTransport) read(conn *wsConn) error {
+
+	stopPing := make(chan struct{})
+	defer close(stopPing)
+	go t.ping(conn, stopPing)
+
+	for {
+		// any frame, including the pong to our ping, extends the deadline
+		conn.setReadDeadline(time.Now().Add(2 * WS_PING_INTERVAL))
+		message, err := conn.readMessage()
+		if err != nil {
+			conn.conn.Close()
+			return err
+		}
+		t.dispatch(message)
+	}
+}
+
+// ping keeps the connection alive and detects dead peers
+func (t *wsTransport) ping(conn *wsConn, stop chan struct{}) {
+	ticker := time.NewTicker(WS_PING_INTERVAL)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			err := conn.writeMessage(wsPing, nil)
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+// dispatch routes a message to the call or subscription it belongs to
+func (t *wsTransport) dispatch(message []byte) {
+
+	message = bytes.TrimSpace(message)
+	if len(message) == 0 {
+		return
+	}
+
+	// a batch response is routed by any of its IDs
+	if message[0] == '[' {
+		var resps []ResponseBase
+		if json.Unmarshal(message, &resps) != nil {
+			return
+		}
+		for _, resp := range resps {
+			if t.resolve(resp.ID, message) {
+				return
+			}
+		}
+		return
+	}
+
+	var msg struct {
+		ID     int64     `json:"id"`
+		Error  *RPCError `json:"error"`
+		Method string    `json:"method"`
+		Params struct {
+			Subscription string          `json:"subscription"`
+			Result       json.RawMessage `json:"result"`
+		} `json:"params"`
+	}
+	if json.Unmarshal(message, &msg) != nil {
+		return
+	}
+
+	switch {
+	case msg.Method == "eth_subscription":
+		t.notify(msg.Params.Subscription, msg.Params.Result)
+	case msg.ID == 0 && msg.Error != nil:
+		// the node couldn't parse a request and doesn't know which one it
+		// was, so every call in flight fails with the error
+		t.resolveAll(message)
+	default:
+		t.resolve(msg.ID, message)
+	}
+}
+
+// resolve hands a response to the call waiting for it
+func (t *wsTransport) resolve(id int64, message []byte) bool {
+
+	t.mu.Lock()
+	p, ok := t.pending[id]
+	if ok {
+		for _, id := range p.ids {
+			delete(t.pending, id)
+		}
+	}
+	t.mu.Unlock()
+
+	if !ok {
+		return false
+	}
+	if p.onResponse != nil {
+		p.onResponse(message)
+	}
+	p.done <- wsResult{body: message}
+	return true
+}
+
+// resolveAll hands a response to every call waiting for one
+func (t *wsTransport) resolveAll(message []byte) {
+
+	t.mu.Lock()
+	pending := t.pending
+	t.pending = make(map[int64]*wsPending)
+	t.mu.Unlock()
+
+	resolved := make(map[*wsPending]bool, len(pending))
+	for _, p := range pending {
+		if resolved[p] {
+			continue
+		}
+		resolved[p] = true
+		if p.onResponse != nil {
+			p.onResponse(message)
+		}
+		p.done <- wsResult{body: message}
+	}
+}
+
+// notify queues a notification for its subscription without blocking the
+// read loop, ending subscriptions whose consumer has fallen too far behind
+func (t *wsTransport) notify(subID string, result json.RawMessage) {
+
+	t.mu.Lock()
+	sub := t.subs[subID]
+	t.mu.Unlock()
+
+	if sub == nil {
+		return
+	}
+	select {
+	case sub.queue <- result:
+	default:
+		go sub.unsubscribe(ErrSubscriptionQueueOverflow)
+	}
+}
+
+// addSubscription routes notifications for subID to sub
+func (t *wsTransport) addSubscription(subID string, sub *Subscription) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.subs[subID] = sub
+}
+
+// removeSubscription stops routing notifications for subID to sub
+func (t *wsTransport) removeSubscription(subID string, sub *Subscription) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.subs[subID] == sub {
+		delete(t.subs, subID)
+	}
+}
+
+// reconnect dials until it succeeds, backing off exponentially, and returns
+// nil if the transport is closed first
+func (t *wsTransport) reconnect() *wsConn {
+
+	backoff := WS_RECONNECT_MIN_BACKOFF
+	for {
+		select {
+		case <-t.closing:
+			return nil
+		case <-time.After(backoff):
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), WS_HANDSHAKE_TIMEOUT)
+		go func() {
+			select {
+			case <-t.closing:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+		conn, err := dialWebSocket(ctx, t.url, t.header, t.tlsConfig)
+		cancel()
+
+		if err == nil {
+			t.mu.Lock()
+			if t.closed {
+				t.mu.Unlock()
+				conn.close()
+				return nil
+			}
+			t.conn = conn
+			t.mu.Unlock()
+			return conn
+		}
+
+		backoff *= 2
+		if backoff > WS_RECONNECT_MAX_BACKOFF {
+			backoff = WS_RECONNECT_MAX_BACKOFF
+		}
+	}
+}
+
+// resubscribe re-issues eth_subscribe for subscriptions of a lost connection
+func (t *wsTransport) resubscribe(subs []*Subscription) {
+	for _, sub := range subs {
+		if sub.isEnded() {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), WS_HANDSHAKE_TIMEOUT)
+		err := sub.client.subscribe(ctx, sub)
+		cancel()
+		if err != nil {
+			sub.end(fmt.Errorf("resubscribe: %w", err))
+		}
+	}
+}
+
+// close closes the transport and its connection
+func (t *wsTransport) close() error {
+
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		return nil
+	}
+	t.closed = true
+	close(t.closing)
+	conn := t.conn
+	t.mu.Unlock()
+
+	// the read loop notices the closed connection and cleans up
+	if conn != nil {
+		return conn.close()
+	}
+	return nil
+}
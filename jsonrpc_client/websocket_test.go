@@ -0,0 +1,396 @@
+package jsonrpc_client
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// wsTestConn is the server side of a WebSocket connection in tests
+type wsTestConn struct {
+	*wsConn
+	number int // 1 for the first connection to the server
+}
+
+// newWSServer starts a server upgrading requests to WebSocket connections
+// served by handle, closed when the test ends. accept computes the
+// Sec-WebSocket-Accept header of the handshake response.
+func newWSServer(t *testing.T, accept func(r *http.Request) string, handle func(conn *wsTestConn)) *httptest.Server {
+	server := httptest.NewServer(wsHandler(t, accept, handle))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// wsHandler upgrades requests to WebSocket connections served by handle
+func wsHandler(t *testing.T, accept func(r *http.Request) string, handle func(conn *wsTestConn)) http.Handler {
+	var conns int32
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, rw, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		fmt.Fprintf(conn, "HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\nSec-WebSocket-Accept: %s\r\n\r\n",
+			accept(r))
+		ws := &wsTestConn{wsConn: &wsConn{conn: conn, reader: rw.Reader}, number: int(atomic.AddInt32(&conns, 1))}
+		go func() {
+			defer conn.Close()
+			handle(ws)
+		}()
+	})
+}
+
+// validAccept returns the Sec-WebSocket-Accept header for the client's key
+func validAccept(r *http.Request) string {
+	return wsAcceptKey(r.Header.Get("Sec-WebSocket-Key"))
+}
+
+// wsURL returns the ws:// URL of a test server
+func wsURL(server *httptest.Server) string {
+	return "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+// writeFrame writes an unmasked frame, as servers do
+func (conn *wsTestConn) writeFrame(fin bool, opcode byte, payload []byte) error {
+	head := []byte{opcode, 0}
+	if fin {
+		head[0] |= 0x80
+	}
+	switch {
+	case len(payload) < 126:
+		head[1] = byte(len(payload))
+	case len(payload) <= 0xffff:
+		head[1] = 126
+		head = binary.BigEndian.AppendUint16(head, uint16(len(payload)))
+	default:
+		head[1] = 127
+		head = binary.BigEndian.AppendUint64(head, uint64(len(payload)))
+	}
+	_, err := conn.conn.Write(append(head, payload...))
+	return err
+}
+
+// readRequest reads the next JSON-RPC request
+func (conn *wsTestConn) readRequest() (*testRequest, error) {
+	message, err := conn.readMessage()
+	if err != nil {
+		return nil, err
+	}
+	var req testRequest
+	err = json.Unmarshal(message, &req)
+	return &req, err
+}
+
+// respond answers a request with a result
+func (conn *wsTestConn) respond(req *testRequest, result interface{}) error {
+	b, err := json.Marshal(testResponse{JSONRPC: "2.0", ID: req.ID, Result: result})
+	if err != nil {
+		return err
+	}
+	return conn.writeFrame(true, wsText, b)
+}
+
+// serveBlockNumber answers every request with block number 42
+func serveBlockNumber(conn *wsTestConn) {
+	for {
+		req, err := conn.readRequest()
+		if err != nil {
+			return
+		}
+		conn.respond(req, "0x2a")
+	}
+}
+
+// dialTestWebSocket connects to a test server, closing the client when the
+// test ends
+func dialTestWebSocket(t *testing.T, server *httptest.Server, opts ...ClientOption) *WebSocketClient {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	client, err := DialWebSocket(ctx, wsURL(server), opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestWebSocketHandshake(t *testing.T) {
+
+	headers := make(chan http.Header, 1)
+	server := newWSServer(t, func(r *http.Request) string {
+		headers <- r.Header.Clone()
+		return validAccept(r)
+	}, serveBlockNumber)
+
+	client := dialTestWebSocket(t, server, WithHeader("X-Api-Key", "secret"))
+	header := <-headers
+	for key, want := range map[string]string{
+		"Upgrade":               "websocket",
+		"Connection":            "Upgrade",
+		"Sec-Websocket-Version": "13",
+		"X-Api-Key":             "secret",
+	} {
+		if got := header.Get(key); got != want {
+			t.Errorf("%s = %q, want %q", key, got, want)
+		}
+	}
+
+	number, err := client.Eth_blockNumber()
+	if err != nil || number != 42 {
+		t.Errorf("Eth_blockNumber() = %d, %v, want 42", number, err)
+	}
+}
+
+func TestWebSocketHandshakeErrors(t *testing.T) {
+
+	badAccept := newWSServer(t, func(*http.Request) string { return "invalid" }, serveBlockNumber)
+	_, err := DialWebSocket(context.Background(), wsURL(badAccept))
+	if err == nil || !strings.Contains(err.Error(), "Sec-WebSocket-Accept") {
+		t.Errorf("DialWebSocket() with a bad accept key = %v", err)
+	}
+
+	forbidden := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+	}))
+	defer forbidden.Close()
+	_, err = DialWebSocket(context.Background(), wsURL(forbidden))
+	if err == nil || !strings.Contains(err.Error(), "403") {
+		t.Errorf("DialWebSocket() with status 403 = %v", err)
+	}
+
+	_, err = DialWebSocket(context.Background(), "http://localhost")
+	if err == nil || !strings.Contains(err.Error(), "unsupported scheme") {
+		t.Errorf("DialWebSocket() of an http URL = %v", err)
+	}
+}
+
+func TestWebSocketTLS(t *testing.T) {
+
+	server := httptest.NewTLSServer(wsHandler(t, validAccept, serveBlockNumber))
+	defer server.Close()
+
+	// the test certificate is only trusted by the server's own client
+	_, err := DialWebSocket(context.Background(), wsURL(server))
+	if err == nil {
+		t.Errorf("DialWebSocket() trusted an unknown certificate")
+	}
+
+	client, err := DialWebSocket(context.Background(), wsURL(server), WithHTTPClient(server.Client()))
+	if err != nil {
+		t.Fatalf("DialWebSocket() with the TLS config of the HTTP client: %v", err)
+	}
+	defer client.Close()
+	number, err := client.Eth_blockNumber()
+	if err != nil || number != 42 {
+		t.Errorf("Eth_blockNumber() = %d, %v, want 42", number, err)
+	}
+}
+
+func TestWebSocketNullIDError(t *testing.T) {
+
+	server := newWSServer(t, validAccept, func(conn *wsTestConn) {
+		_, err := conn.readRequest()
+		if err != nil {
+			return
+		}
+		conn.writeFrame(true, wsText, []byte(`{"jsonrpc":"2.0","id":null,"error":{"code":-32700,"message":"parse error"}}`))
+		serveBlockNumber(conn)
+	})
+
+	client := dialTestWebSocket(t, server)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	// the error can't be matched to a request, so it fails the call in
+	// flight rather than leaving it waiting
+	_, err := client.Eth_blockNumberContext(ctx)
+	var rpcErr *RPCError
+	if !errors.As(err, &rpcErr) || rpcErr.Code != PARSE_ERROR_CODE {
+		t.Errorf("Eth_blockNumber() = %v, want a parse error", err)
+	}
+
+	number, err := client.Eth_blockNumberContext(ctx)
+	if err != nil || number != 42 {
+		t.Errorf("Eth_blockNumber() = %d, %v, want 42", number, err)
+	}
+}
+
+func TestWebSocketFragmentsAndPing(t *testing.T) {
+
+	pong := make(chan []byte, 1)
+	server := newWSServer(t, validAccept, func(conn *wsTestConn) {
+		req, err := conn.readRequest()
+		if err != nil {
+			return
+		}
+		resp := fmt.Sprintf(`{"jsonrpc":"2.0","id":%s,"result":"0x2a"}`, req.ID)
+
+		// the response is split over three frames, with a ping in between
+		conn.writeFrame(false, wsText, []byte(resp[:10]))
+		conn.writeFrame(true, wsPing, []byte("ping"))
+		conn.writeFrame(false, wsContinuation, []byte(resp[10:20]))
+		conn.writeFrame(true, wsContinuation, []byte(resp[20:]))
+
+		_, opcode, payload, err := conn.readFrame()
+		if err == nil && opcode == wsPong {
+			pong <- payload
+		}
+		serveBlockNumber(conn)
+	})
+
+	client := dialTestWebSocket(t, server)
+	number, err := client.Eth_blockNumber()
+	if err != nil || number != 42 {
+		t.Errorf("Eth_blockNumber() = %d, %v, want 42", number, err)
+	}
+	select {
+	case payload := <-pong:
+		if string(payload) != "ping" {
+			t.Errorf("pong payload %q, want %q", payload, "ping")
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("no pong received")
+	}
+}
+
+func TestWebSocketServerClose(t *testing.T) {
+
+	closeReply := make(chan []byte, 1)
+	server := newWSServer(t, validAccept, func(conn *wsTestConn) {
+		if conn.number > 1 {
+			serveBlockNumber(conn)
+			return
+		}
+		// the first connection is closed with a call in flight
+		_, err := conn.readRequest()
+		if err != nil {
+			return
+		}
+		conn.writeFrame(true, wsClose, []byte{0x03, 0xe9}) // 1001, going away
+		_, opcode, payload, err := conn.readFrame()
+		if err == nil && opcode == wsClose {
+			closeReply <- payload
+		}
+	})
+
+	client := dialTestWebSocket(t, server)
+	_, err := client.Eth_blockNumber()
+	if err == nil || !strings.Contains(err.Error(), "connection lost") {
+		t.Errorf("Eth_blockNumber() = %v, want a lost connection", err)
+	}
+	select {
+	case payload := <-closeReply:
+		if string(payload) != "\x03\xe9" {
+			t.Errorf("close reply %x, want the status code echoed", payload)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("no close reply received")
+	}
+
+	// the client reconnects
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		number, err := client.Eth_blockNumber()
+		if err == nil && number == 42 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("no reconnection: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestWebSocketResubscribe(t *testing.T) {
+
+	server := newWSServer(t, validAccept, func(conn *wsTestConn) {
+		for {
+			req, err := conn.readRequest()
+			if err != nil {
+				return
+			}
+			if req.Method != "eth_subscribe" {
+				conn.respond(req, true)
+				continue
+			}
+			subID := fmt.Sprintf("0x%d", conn.number)
+			conn.respond(req, subID)
+			notification := fmt.Sprintf(`{"jsonrpc":"2.0","method":"eth_subscription","params":{"subscription":"%s","result":%d}}`, subID, conn.number)
+			conn.writeFrame(true, wsText, []byte(notification))
+
+			// the first connection drops after its notification
+			if conn.number == 1 {
+				return
+			}
+		}
+	})
+
+	client := dialTestWebSocket(t, server)
+	ch := make(chan json.RawMessage, 2)
+	sub, err := client.Eth_subscribe(ch, "newPendingTransactions")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for want := 1; want <= 2; want++ {
+		select {
+		case result := <-ch:
+			if string(result) != fmt.Sprint(want) {
+				t.Errorf("notification %s, want %d", result, want)
+			}
+		case err := <-sub.Err():
+			t.Fatalf("subscription ended: %v", err)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("notification %d not received", want)
+		}
+	}
+	if sub.ID() != "0x2" {
+		t.Errorf("ID() = %s after resubscribing, want 0x2", sub.ID())
+	}
+
+	client.Close()
+	select {
+	case err := <-sub.Err():
+		if err != ErrClientClosed {
+			t.Errorf("subscription ended with %v, want %v", err, ErrClientClosed)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("subscription not ended by Close")
+	}
+}
+
+// TestWebSocketLargeFrames checks the extended payload lengths
+func TestWebSocketLargeFrames(t *testing.T) {
+
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+	clientConn := &wsConn{conn: client, reader: bufio.NewReader(client)}
+	serverConn := &wsTestConn{wsConn: &wsConn{conn: server, reader: bufio.NewReader(server)}}
+
+	for _, size := range []int{125, 126, 0xffff, 0x10000} {
+		payload := []byte(strings.Repeat("x", size))
+		go serverConn.writeFrame(true, wsText, payload)
+		message, err := clientConn.readMessage()
+		if err != nil || len(message) != size {
+			t.Errorf("size %d: got %d bytes, %v", size, len(message), err)
+		}
+
+		// client frames are masked
+		go clientConn.writeMessage(wsText, payload)
+		_, _, received, err := serverConn.readFrame()
+		if err != nil || string(received) != string(payload) {
+			t.Errorf("size %d: server got %d bytes, %v", size, len(received), err)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package jsonrpc_client
+
+import "encoding/json"
+
+// Withdrawal is a validator withdrawal from the beacon chain (EIP-4895)
+type Withdrawal struct {
+	Index          uint64  `json:"index"`
+	ValidatorIndex uint64  `json:"validator_index"`
+	Address        Address `json:"address"`
+	Amount         uint64  `json:"amount"` // in Gwei
+}
+
+// withdrawalJSON is the JSON-RPC form of a Withdrawal
+type withdrawalJSON struct {
+	Index          HexUint64 `json:"index"`
+	ValidatorIndex HexUint64 `json:"validatorIndex"`
+	Address        Address   `json:"address"`
+	Amount         HexUint64 `json:"amount"` // in Gwei
+}
+
+// storedWithdrawal is the snake_case form of a Withdrawal
+type storedWithdrawal Withdrawal
+
+// MarshalJSON encodes the withdrawal in the JSON-RPC form
+func (withdrawal Withdrawal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&withdrawalJSON{
+		Index:          HexUint64(withdrawal.Index),
+		ValidatorIndex: HexUint64(withdrawal.ValidatorIndex),
+		Address:        withdrawal.Address,
+		Amount:         HexUint64(withdrawal.Amount),
+	})
+}
+
+// UnmarshalJSON decodes a withdrawal in the JSON-RPC form
+func (withdrawal *Withdrawal) UnmarshalJSON(b []byte) error {
+	var dec withdrawalJSON
+	err := json.Unmarshal(b, &dec)
+	if err != nil {
+		return err
+	}
+	*withdrawal = Withdrawal{
+		Index:          uint64(dec.Index),
+		ValidatorIndex: uint64(dec.ValidatorIndex),
+		Address:        dec.Address,
+		Amount:         uint64(dec.Amount),
+	}
+	return nil
+}
+
+// NewWithdrawalFromJSON creates a new Withdrawal from its snake_case stored
+// form
+func NewWithdrawalFromJSON(b []byte) (*Withdrawal, error) {
+	var stored storedWithdrawal
+	err := json.Unmarshal(b, &stored)
+	if err != nil {
+		return nil, err
+	}
+	withdrawal := Withdrawal(stored)
+	return &withdrawal, nil
+}
+
+// ToJSON marshals a Withdrawal into its snake_case stored form
+func (withdrawal *Withdrawal) ToJSON() ([]byte, error) {
+	s, err := json.Marshal((*storedWithdrawal)(withdrawal))
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Equals determines whether two Withdrawals are equal
+func (withdrawal *Withdrawal) Equals(withdrawal2 *Withdrawal) bool {
+	return *withdrawal == *withdrawal2
+}
@@ -0,0 +1,283 @@
+package rlp
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+)
+
+// Decoder is implemented by types with a custom decoding
+type Decoder interface {
+	// DecodeRLP decodes a single value from its complete encoding
+	DecodeRLP(b []byte) error
+}
+
+var decoderType = reflect.TypeOf((*Decoder)(nil)).Elem()
+
+// Decode decodes b, which must hold exactly one value, into the value val
+// points to, following the rules of EncodeToBytes. Non-canonical encodings
+// are rejected. Missing optional struct fields are left zero, empty values
+// decode into nil pointers other than *big.Int, and a decoded interface{} holds []byte for
+// strings and []interface{} for lists.
+func Decode(b []byte, val interface{}) error {
+
+	v := reflect.ValueOf(val)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return errors.New("rlp: Decode requires a non-nil pointer")
+	}
+
+	_, _, rest, err := Split(b)
+	if err != nil {
+		return err
+	}
+	if len(rest) > 0 {
+		return ErrMoreThanOneValue
+	}
+	return decodeValue(b, v.Elem())
+}
+
+// decodeValue decodes item, a complete encoding, into v by reflection
+func decodeValue(item []byte, v reflect.Value) error {
+
+	t := v.Type()
+	switch {
+	case t == rawValueType:
+		v.SetBytes(append([]byte{}, item...))
+		return nil
+	case reflect.PtrTo(t).Implements(decoderType):
+		return v.Addr().Interface().(Decoder).DecodeRLP(item)
+	case t == bigIntType:
+		i, err := decodeBigInt(item)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(*i))
+		return nil
+	case t.Kind() == reflect.Ptr && t.Elem() == bigIntType:
+		i, err := decodeBigInt(item)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(i))
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		content, _, err := SplitString(item)
+		if err != nil {
+			return err
+		}
+		switch {
+		case len(content) == 0:
+			v.SetBool(false)
+		case len(content) == 1 && content[0] == 0x01:
+			v.SetBool(true)
+		default:
+			return fmt.Errorf("rlp: invalid boolean value %x", content)
+		}
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		i, err := decodeUint(item, int(t.Size()))
+		if err != nil {
+			return err
+		}
+		v.SetUint(i)
+		return nil
+	case reflect.String:
+		content, _, err := SplitString(item)
+		if err != nil {
+			return err
+		}
+		v.SetString(string(content))
+		return nil
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			content, _, err := SplitString(item)
+			if err != nil {
+				return err
+			}
+			v.SetBytes(append([]byte{}, content...))
+			return nil
+		}
+		return decodeList(item, v)
+	case reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			content, _, err := SplitString(item)
+			if err != nil {
+				return err
+			}
+			if len(content) != v.Len() {
+				return fmt.Errorf("rlp: input string has %d bytes, want %d for %v", len(content), v.Len(), t)
+			}
+			reflect.Copy(v, reflect.ValueOf(content))
+			return nil
+		}
+		return decodeList(item, v)
+	case reflect.Struct:
+		return decodeStruct(item, v)
+	case reflect.Ptr:
+		// the empty value nil pointers encode to decodes back to nil
+		if bytes.Equal(item, nilEncoding(t.Elem())) {
+			v.Set(reflect.Zero(t))
+			return nil
+		}
+		if v.IsNil() {
+			v.Set(reflect.New(t.Elem()))
+		}
+		return decodeValue(item, v.Elem())
+	case reflect.Interface:
+		if t.NumMethod() != 0 {
+			break
+		}
+		generic, err := decodeGeneric(item)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(generic))
+		return nil
+	}
+
+	return fmt.Errorf("rlp: type %v is not RLP-serializable", t)
+}
+
+// decodeList decodes the items of a list into a slice or array
+func decodeList(item []byte, v reflect.Value) error {
+
+	content, _, err := SplitList(item)
+	if err != nil {
+		return err
+	}
+	var items [][]byte
+	for len(content) > 0 {
+		_, _, rest, err := Split(content)
+		if err != nil {
+			return err
+		}
+		items = append(items, content[:len(content)-len(rest)])
+		content = rest
+	}
+
+	if v.Kind() == reflect.Slice {
+		v.Set(reflect.MakeSlice(v.Type(), len(items), len(items)))
+	} else if len(items) < v.Len() {
+		return ErrTooFewElements
+	} else if len(items) > v.Len() {
+		return ErrTooManyElements
+	}
+	for i, elem := range items {
+		err = decodeValue(elem, v.Index(i))
+		if err != nil {
+			return fmt.Errorf("[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// decodeStruct decodes the items of a list into the fields of a struct
+func decodeStruct(item []byte, v reflect.Value) error {
+
+	fields, err := structFields(v.Type())
+	if err != nil {
+		return err
+	}
+	content, _, err := SplitList(item)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range fields {
+		fv := v.Field(f.index)
+		if f.tail {
+			err = decodeList(EncodeList(content), fv)
+			if err != nil {
+				return fmt.Errorf("%s: %w", f.name, err)
+			}
+			content = nil
+			continue
+		}
+		if len(content) == 0 {
+			if f.optional {
+				fv.Set(reflect.Zero(fv.Type()))
+				continue
+			}
+			return fmt.Errorf("%s: %w", f.name, ErrTooFewElements)
+		}
+		_, _, rest, err := Split(content)
+		if err != nil {
+			return fmt.Errorf("%s: %w", f.name, err)
+		}
+		err = decodeValue(content[:len(content)-len(rest)], fv)
+		if err != nil {
+			return fmt.Errorf("%s: %w", f.name, err)
+		}
+		content = rest
+	}
+
+	if len(content) > 0 {
+		return fmt.Errorf("%v: %w", v.Type(), ErrTooManyElements)
+	}
+	return nil
+}
+
+// decodeUint decodes an unsigned integer of at most size bytes
+func decodeUint(item []byte, size int) (uint64, error) {
+
+	content, _, err := SplitString(item)
+	if err != nil {
+		return 0, err
+	}
+	if len(content) > size {
+		return 0, ErrUintOverflow
+	}
+	if len(content) > 0 && content[0] == 0 {
+		return 0, ErrCanonInt
+	}
+	var i uint64
+	for _, b := range content {
+		i = i<<8 | uint64(b)
+	}
+	return i, nil
+}
+
+// decodeBigInt decodes a non-negative big.Int
+func decodeBigInt(item []byte) (*big.Int, error) {
+
+	content, _, err := SplitString(item)
+	if err != nil {
+		return nil, err
+	}
+	if len(content) > 0 && content[0] == 0 {
+		return nil, ErrCanonInt
+	}
+	return new(big.Int).SetBytes(content), nil
+}
+
+// decodeGeneric decodes item into []byte or []interface{}
+func decodeGeneric(item []byte) (interface{}, error) {
+
+	k, content, _, err := Split(item)
+	if err != nil {
+		return nil, err
+	}
+	if k != List {
+		return append([]byte{}, content...), nil
+	}
+
+	list := []interface{}{}
+	for len(content) > 0 {
+		_, _, rest, err := Split(content)
+		if err != nil {
+			return nil, err
+		}
+		elem, err := decodeGeneric(content[:len(content)-len(rest)])
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, elem)
+		content = rest
+	}
+	return list, nil
+}
@@ -0,0 +1,122 @@
+package rlp
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func TestDecodeRoundTrip(t *testing.T) {
+	for _, test := range encodingTests {
+		b := mustHex(t, test.want)
+		var got interface{}
+		if err := Decode(b, &got); err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		enc, err := EncodeToBytes(got)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if !bytes.Equal(enc, b) {
+			t.Errorf("%s: got %x, want %x", test.name, enc, b)
+		}
+	}
+}
+
+func TestDecodeTyped(t *testing.T) {
+
+	var s string
+	if err := Decode(mustHex(t, "83646f67"), &s); err != nil || s != "dog" {
+		t.Errorf("string: got %q, %v", s, err)
+	}
+
+	var u uint64
+	if err := Decode(mustHex(t, "830186a0"), &u); err != nil || u != 100000 {
+		t.Errorf("uint64: got %d, %v", u, err)
+	}
+
+	var i *big.Int
+	b := mustHex(t, "a1010000000000000000000000000000000000000000000000000000000000000000")
+	want := new(big.Int).Lsh(big.NewInt(1), 256)
+	if err := Decode(b, &i); err != nil || i.Cmp(want) != 0 {
+		t.Errorf("big.Int: got %v, %v", i, err)
+	}
+
+	var list []string
+	if err := Decode(mustHex(t, "cc83646f6783676f6483636174"), &list); err != nil || !reflect.DeepEqual(list, []string{"dog", "god", "cat"}) {
+		t.Errorf("[]string: got %q, %v", list, err)
+	}
+
+	var arr [3]byte
+	if err := Decode(mustHex(t, "83010203"), &arr); err != nil || arr != [3]byte{1, 2, 3} {
+		t.Errorf("[3]byte: got %x, %v", arr, err)
+	}
+
+	var opt optionalStruct
+	if err := Decode(mustHex(t, "c3018003"), &opt); err != nil || opt.A != 1 || opt.B != 0 || opt.C.Int64() != 3 {
+		t.Errorf("optional: got %+v, %v", opt, err)
+	}
+
+	var tail tailStruct
+	if err := Decode(mustHex(t, "c30102c0"), &tail); err != nil || !reflect.DeepEqual(tail.Tail, []RawValue{{0x02}, {0xc0}}) {
+		t.Errorf("tail: got %+v, %v", tail, err)
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	tests := []struct {
+		input string
+		val   interface{}
+		want  error
+	}{
+		// non-canonical single bytes and sizes, from ethereum/tests invalidRLPTest
+		{"8100", new(interface{}), ErrCanonSize},
+		{"8101", new(interface{}), ErrCanonSize},
+		{"817f", new(interface{}), ErrCanonSize},
+		{"b800", new(interface{}), ErrCanonSize},
+		{"b837" + "00", new(interface{}), ErrCanonSize},
+		{"b90000", new(interface{}), ErrCanonSize},
+		{"f800", new(interface{}), ErrCanonSize},
+		// leading zeros in integers
+		{"820001", new(uint64), ErrCanonInt},
+		{"820001", new(*big.Int), ErrCanonInt},
+		{"00", new(uint64), ErrCanonInt},
+		// truncated input
+		{"", new(interface{}), io.ErrUnexpectedEOF},
+		{"81", new(interface{}), ErrValueTooLarge},
+		{"83646f", new(interface{}), ErrValueTooLarge},
+		{"c28300", new(interface{}), ErrValueTooLarge},
+		{"b9ffff", new(interface{}), ErrValueTooLarge},
+		// trailing data
+		{"8000", new(interface{}), ErrMoreThanOneValue},
+		{"c0c0", new(interface{}), ErrMoreThanOneValue},
+		// type mismatches
+		{"c0", new(uint64), ErrExpectedString},
+		{"c0", new(string), ErrExpectedString},
+		{"80", new([]string), ErrExpectedList},
+		{"8901ffffffffffffffff", new(uint64), ErrUintOverflow},
+		{"c0", new(tailStruct), ErrTooFewElements},
+		{"c401020304", new(optionalStruct), ErrTooManyElements},
+	}
+	for _, test := range tests {
+		err := Decode(mustHex(t, test.input), test.val)
+		if !errors.Is(err, test.want) {
+			t.Errorf("%s into %T: got %v, want %v", test.input, test.val, err, test.want)
+		}
+	}
+}
+
+func TestDecodeNilPointer(t *testing.T) {
+	if err := Decode([]byte{0x80}, nil); err == nil {
+		t.Error("expected an error")
+	}
+	var u uint64
+	if err := Decode([]byte{0x80}, u); err == nil {
+		t.Error("expected an error")
+	}
+}
@@ -0,0 +1,231 @@
+// Package rlp implements the Recursive Length Prefix encoding used by
+// Ethereum for consensus data
+package rlp
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"reflect"
+)
+
+// Encoder is implemented by types with a custom encoding
+type Encoder interface {
+	// EncodeRLP returns the encoding of a single value
+	EncodeRLP() ([]byte, error)
+}
+
+// RawValue is an already encoded value, which is encoded and decoded as is
+type RawValue []byte
+
+var (
+	bigIntType   = reflect.TypeOf(big.Int{})
+	rawValueType = reflect.TypeOf(RawValue{})
+	encoderType  = reflect.TypeOf((*Encoder)(nil)).Elem()
+)
+
+// EmptyString is the encoding of an empty byte string, also used for zero
+var EmptyString = []byte{0x80}
+
+// EmptyList is the encoding of an empty list
+var EmptyList = []byte{0xc0}
+
+// EncodeBytes encodes a byte string
+func EncodeBytes(b []byte) []byte {
+	if len(b) == 1 && b[0] < 0x80 {
+		return []byte{b[0]}
+	}
+	return append(encodeHeader(0x80, uint64(len(b))), b...)
+}
+
+// EncodeUint encodes an unsigned integer as its big-endian bytes without
+// leading zeros
+func EncodeUint(i uint64) []byte {
+	if i == 0 {
+		return EmptyString
+	}
+	if i < 0x80 {
+		return []byte{byte(i)}
+	}
+	return EncodeBytes(putUint(i))
+}
+
+// EncodeBigInt encodes a non-negative big.Int like an unsigned integer. A nil
+// big.Int encodes as zero.
+func EncodeBigInt(i *big.Int) ([]byte, error) {
+	if i == nil {
+		return EmptyString, nil
+	}
+	if i.Sign() < 0 {
+		return nil, ErrNegativeBigInt
+	}
+	return EncodeBytes(i.Bytes()), nil
+}
+
+// EncodeList encodes a list from the encodings of its items
+func EncodeList(items ...[]byte) []byte {
+	var size uint64
+	for _, item := range items {
+		size += uint64(len(item))
+	}
+	enc := encodeHeader(0xc0, size)
+	for _, item := range items {
+		enc = append(enc, item...)
+	}
+	return enc
+}
+
+// encodeHeader encodes the prefix of a string (offset 0x80) or list (offset
+// 0xc0) whose payload is size bytes long
+func encodeHeader(offset byte, size uint64) []byte {
+	if size < 56 {
+		return []byte{offset + byte(size)}
+	}
+	sizeBytes := putUint(size)
+	return append([]byte{offset + 55 + byte(len(sizeBytes))}, sizeBytes...)
+}
+
+// putUint returns the big-endian bytes of i without leading zeros
+func putUint(i uint64) []byte {
+	var b []byte
+	for ; i > 0; i >>= 8 {
+		b = append([]byte{byte(i)}, b...)
+	}
+	return b
+}
+
+// Encode writes the encoding of val to w
+func Encode(w io.Writer, val interface{}) error {
+	enc, err := EncodeToBytes(val)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(enc)
+	return err
+}
+
+// EncodeToBytes returns the encoding of val. Unsigned integers, bools,
+// big.Ints, strings and byte slices and arrays encode as strings; other
+// slices and arrays and structs encode as lists. Struct fields are encoded in
+// order and support these tags:
+//
+//	rlp:"-"        the field is ignored
+//	rlp:"optional" the field is omitted when it and all later fields are zero
+//	rlp:"tail"     the field, a final slice, holds the remaining list items
+//
+// Nil pointers encode as the empty value of their element type.
+func EncodeToBytes(val interface{}) ([]byte, error) {
+	return encodeValue(reflect.ValueOf(val))
+}
+
+// encodeValue encodes a value by reflection
+func encodeValue(v reflect.Value) ([]byte, error) {
+
+	if !v.IsValid() {
+		return EmptyList, nil
+	}
+	t := v.Type()
+
+	switch {
+	case t == rawValueType:
+		return append([]byte{}, v.Bytes()...), nil
+	case t.Implements(encoderType):
+		if t.Kind() == reflect.Ptr && v.IsNil() {
+			return nilEncoding(t.Elem()), nil
+		}
+		return v.Interface().(Encoder).EncodeRLP()
+	case v.CanAddr() && reflect.PtrTo(t).Implements(encoderType):
+		return v.Addr().Interface().(Encoder).EncodeRLP()
+	case t == bigIntType:
+		i := v.Interface().(big.Int)
+		return EncodeBigInt(&i)
+	case t.Kind() == reflect.Ptr && t.Elem() == bigIntType:
+		return EncodeBigInt(v.Interface().(*big.Int))
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			return []byte{0x01}, nil
+		}
+		return EmptyString, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return EncodeUint(v.Uint()), nil
+	case reflect.String:
+		return EncodeBytes([]byte(v.String())), nil
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, v.Len())
+			reflect.Copy(reflect.ValueOf(b), v)
+			return EncodeBytes(b), nil
+		}
+		items := make([][]byte, v.Len())
+		for i := range items {
+			enc, err := encodeValue(v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			items[i] = enc
+		}
+		return EncodeList(items...), nil
+	case reflect.Struct:
+		return encodeStruct(v)
+	case reflect.Ptr:
+		if v.IsNil() {
+			return nilEncoding(t.Elem()), nil
+		}
+		return encodeValue(v.Elem())
+	case reflect.Interface:
+		return encodeValue(v.Elem())
+	}
+
+	return nil, fmt.Errorf("rlp: type %v is not RLP-serializable", t)
+}
+
+// encodeStruct encodes the fields of a struct as a list
+func encodeStruct(v reflect.Value) ([]byte, error) {
+
+	fields, err := structFields(v.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	// trailing zero optional fields are omitted
+	end := len(fields)
+	for end > 0 && fields[end-1].optional && v.Field(fields[end-1].index).IsZero() {
+		end--
+	}
+
+	var items [][]byte
+	for _, f := range fields[:end] {
+		fv := v.Field(f.index)
+		if !f.tail {
+			enc, err := encodeValue(fv)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", f.name, err)
+			}
+			items = append(items, enc)
+			continue
+		}
+		for i := 0; i < fv.Len(); i++ {
+			enc, err := encodeValue(fv.Index(i))
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", f.name, err)
+			}
+			items = append(items, enc)
+		}
+	}
+	return EncodeList(items...), nil
+}
+
+// nilEncoding returns the encoding of a nil pointer to t
+func nilEncoding(t reflect.Type) []byte {
+	switch {
+	case t == bigIntType:
+		return EmptyString
+	case t.Kind() == reflect.Struct,
+		(t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && t.Elem().Kind() != reflect.Uint8:
+		return EmptyList
+	}
+	return EmptyString
+}
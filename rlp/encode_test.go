@@ -0,0 +1,158 @@
+package rlp
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+const (
+	lorem55 = "Lorem ipsum dolor sit amet, consectetur adipisicing eli"
+	lorem56 = "Lorem ipsum dolor sit amet, consectetur adipisicing elit"
+)
+
+func mustHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func bigFromString(s string) *big.Int {
+	i, _ := new(big.Int).SetString(s, 10)
+	return i
+}
+
+// encodingTests are taken from the ethereum/tests RLP test suite
+var encodingTests = []struct {
+	name string
+	val  interface{}
+	want string
+}{
+	{"emptystring", "", "80"},
+	{"bytestring00", "\x00", "00"},
+	{"bytestring01", "\x01", "01"},
+	{"bytestring7F", "\x7f", "7f"},
+	{"shortstring", "dog", "83646f67"},
+	{"shortstring2", lorem55, "b7" + hex.EncodeToString([]byte(lorem55))},
+	{"longstring", lorem56, "b838" + hex.EncodeToString([]byte(lorem56))},
+	{"zero", uint64(0), "80"},
+	{"smallint", uint64(1), "01"},
+	{"smallint2", uint64(16), "10"},
+	{"smallint3", uint64(79), "4f"},
+	{"smallint4", uint64(127), "7f"},
+	{"mediumint1", uint64(128), "8180"},
+	{"mediumint2", uint64(1000), "8203e8"},
+	{"mediumint3", uint64(100000), "830186a0"},
+	{"mediumint4", bigFromString("83729609699884896815286331701780722"), "8f102030405060708090a0b0c0d0e0f2"},
+	{"mediumint5", bigFromString("105315505618206987246253880190783558935785933862974822347068935681"), "9c0100020003000400050006000700080009000a000b000c000d000e01"},
+	{"bigint", bigFromString("115792089237316195423570985008687907853269984665640564039457584007913129639936"), "a1010000000000000000000000000000000000000000000000000000000000000000"},
+	{"emptylist", []interface{}{}, "c0"},
+	{"stringlist", []interface{}{"dog", "god", "cat"}, "cc83646f6783676f6483636174"},
+	{"multilist", []interface{}{"zw", []interface{}{uint64(4)}, uint64(1)}, "c6827a77c10401"},
+	{"listsoflists", []interface{}{[]interface{}{[]interface{}{}, []interface{}{}}, []interface{}{}}, "c4c2c0c0c0"},
+	{"listsoflists2", []interface{}{[]interface{}{}, []interface{}{[]interface{}{}}, []interface{}{[]interface{}{}, []interface{}{[]interface{}{}}}}, "c7c0c1c0c3c0c1c0"},
+	{"dictTest1", []interface{}{
+		[]interface{}{"key1", "val1"},
+		[]interface{}{"key2", "val2"},
+		[]interface{}{"key3", "val3"},
+		[]interface{}{"key4", "val4"},
+	}, "ecca846b6579318476616c31ca846b6579328476616c32ca846b6579338476616c33ca846b6579348476616c34"},
+	{"bigint0", big.NewInt(0), "80"},
+	{"nilbigint", (*big.Int)(nil), "80"},
+	{"bytesarray", [3]byte{1, 2, 3}, "83010203"},
+	{"bool", true, "01"},
+	{"boolfalse", false, "80"},
+	{"raw", RawValue{0xc1, 0x80}, "c180"},
+}
+
+func TestEncodeToBytes(t *testing.T) {
+	for _, test := range encodingTests {
+		got, err := EncodeToBytes(test.val)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if hex.EncodeToString(got) != test.want {
+			t.Errorf("%s: got %x, want %s", test.name, got, test.want)
+		}
+	}
+}
+
+func TestEncodeLongList(t *testing.T) {
+	items := make([]interface{}, 20)
+	for i := range items {
+		items[i] = "asdf"
+	}
+	got, err := EncodeToBytes(items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "f864" + strings.Repeat("8461736466", 20)
+	if hex.EncodeToString(got) != want {
+		t.Errorf("got %x, want %s", got, want)
+	}
+}
+
+func TestEncodeNegativeBigInt(t *testing.T) {
+	if _, err := EncodeToBytes(big.NewInt(-1)); !errors.Is(err, ErrNegativeBigInt) {
+		t.Errorf("got %v, want %v", err, ErrNegativeBigInt)
+	}
+}
+
+type optionalStruct struct {
+	A uint64
+	B uint64   `rlp:"optional"`
+	C *big.Int `rlp:"optional"`
+	D string   `rlp:"-"`
+}
+
+type tailStruct struct {
+	A    uint64
+	Tail []RawValue `rlp:"tail"`
+}
+
+func TestEncodeStruct(t *testing.T) {
+	tests := []struct {
+		val  interface{}
+		want string
+	}{
+		{optionalStruct{A: 1}, "c101"},
+		{optionalStruct{A: 1, C: big.NewInt(3)}, "c3018003"},
+		{optionalStruct{A: 1, B: 2, D: "ignored"}, "c20102"},
+		{tailStruct{A: 1, Tail: []RawValue{{0x02}, {0xc0}}}, "c30102c0"},
+		{(*optionalStruct)(nil), "c0"},
+	}
+	for _, test := range tests {
+		got, err := EncodeToBytes(test.val)
+		if err != nil {
+			t.Errorf("%+v: unexpected error: %v", test.val, err)
+			continue
+		}
+		if hex.EncodeToString(got) != test.want {
+			t.Errorf("%+v: got %x, want %s", test.val, got, test.want)
+		}
+	}
+}
+
+func TestEncodePrimitives(t *testing.T) {
+	if got := EncodeBytes([]byte{0x7f}); !bytes.Equal(got, []byte{0x7f}) {
+		t.Errorf("EncodeBytes: got %x", got)
+	}
+	if got := EncodeUint(0x0400); !bytes.Equal(got, []byte{0x82, 0x04, 0x00}) {
+		t.Errorf("EncodeUint: got %x", got)
+	}
+	got := EncodeList(EncodeBytes([]byte("cat")), EncodeBytes([]byte("dog")))
+	if want := mustHex(t, "c88363617483646f67"); !bytes.Equal(got, want) {
+		t.Errorf("EncodeList: got %x, want %x", got, want)
+	}
+	var buf bytes.Buffer
+	if err := Encode(&buf, uint64(1024)); err != nil || hex.EncodeToString(buf.Bytes()) != "820400" {
+		t.Errorf("Encode: got %x, %v", buf.Bytes(), err)
+	}
+}
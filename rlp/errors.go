@@ -0,0 +1,28 @@
+package rlp
+
+import "errors"
+
+var (
+	// ErrNegativeBigInt is returned when encoding a negative big.Int, which
+	// RLP cannot represent
+	ErrNegativeBigInt = errors.New("rlp: cannot encode negative big.Int")
+
+	// ErrExpectedString is returned when decoding a list into a string type
+	ErrExpectedString = errors.New("rlp: expected string or byte")
+	// ErrExpectedList is returned when decoding a string into a list type
+	ErrExpectedList = errors.New("rlp: expected list")
+	// ErrCanonInt is returned for integers encoded with leading zero bytes
+	ErrCanonInt = errors.New("rlp: non-canonical integer (leading zero bytes)")
+	// ErrCanonSize is returned for sizes not encoded in the shortest form
+	ErrCanonSize = errors.New("rlp: non-canonical size information")
+	// ErrValueTooLarge is returned when a size exceeds the remaining input
+	ErrValueTooLarge = errors.New("rlp: value size exceeds available input length")
+	// ErrMoreThanOneValue is returned when input holds data after its value
+	ErrMoreThanOneValue = errors.New("rlp: input contains more than one value")
+	// ErrUintOverflow is returned when an integer doesn't fit its type
+	ErrUintOverflow = errors.New("rlp: uint overflow")
+	// ErrTooFewElements is returned when a list is shorter than its type
+	ErrTooFewElements = errors.New("rlp: too few elements")
+	// ErrTooManyElements is returned when a list is longer than its type
+	ErrTooManyElements = errors.New("rlp: too many elements")
+)
@@ -0,0 +1,68 @@
+package rlp
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// field is an encoded struct field
+type field struct {
+	index    int
+	name     string
+	optional bool
+	tail     bool
+}
+
+// fieldCache holds the fields of each struct type
+var fieldCache sync.Map // map[reflect.Type][]field
+
+// structFields returns the encoded fields of a struct type, in order
+func structFields(t reflect.Type) ([]field, error) {
+
+	if cached, ok := fieldCache.Load(t); ok {
+		return cached.([]field), nil
+	}
+
+	var fields []field
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" {
+			continue // unexported
+		}
+		f := field{index: i, name: sf.Name}
+		for _, tag := range strings.Split(sf.Tag.Get("rlp"), ",") {
+			switch strings.TrimSpace(tag) {
+			case "":
+			case "-":
+				f.index = -1
+			case "optional":
+				f.optional = true
+			case "tail":
+				f.tail = true
+				if sf.Type.Kind() != reflect.Slice {
+					return nil, fmt.Errorf("rlp: tail field %v.%s is not a slice", t, sf.Name)
+				}
+			default:
+				return nil, fmt.Errorf("rlp: unknown tag %q on %v.%s", tag, t, sf.Name)
+			}
+		}
+		if f.index < 0 {
+			continue
+		}
+
+		if n := len(fields); n > 0 {
+			if fields[n-1].tail {
+				return nil, fmt.Errorf("rlp: tail field %v.%s is not last", t, fields[n-1].name)
+			}
+			if fields[n-1].optional && !f.optional && !f.tail {
+				return nil, fmt.Errorf("rlp: field %v.%s must be optional after an optional field", t, sf.Name)
+			}
+		}
+		fields = append(fields, f)
+	}
+
+	fieldCache.Store(t, fields)
+	return fields, nil
+}
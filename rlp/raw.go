@@ -0,0 +1,115 @@
+package rlp
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// Kind is the kind of an encoded item
+type Kind int
+
+const (
+	// Byte is a single byte below 0x80, its own encoding
+	Byte Kind = iota
+	// String is a byte string
+	String
+	// List is a list of items
+	List
+)
+
+// Split splits the first item off b, returning its kind, its content and the
+// bytes following it. It rejects sizes not encoded canonically.
+func Split(b []byte) (k Kind, content, rest []byte, err error) {
+
+	if len(b) == 0 {
+		return 0, nil, nil, io.ErrUnexpectedEOF
+	}
+
+	prefix := b[0]
+	var offset, size uint64
+	switch {
+	case prefix < 0x80:
+		return Byte, b[:1], b[1:], nil
+	case prefix < 0xb8:
+		k, offset, size = String, 1, uint64(prefix-0x80)
+		// a single byte below 0x80 is its own encoding
+		if size == 1 && len(b) > 1 && b[1] < 0x80 {
+			return 0, nil, nil, ErrCanonSize
+		}
+	case prefix < 0xc0:
+		k = String
+		offset, size, err = readSize(b[1:], prefix-0xb7)
+	case prefix < 0xf8:
+		k, offset, size = List, 1, uint64(prefix-0xc0)
+	default:
+		k = List
+		offset, size, err = readSize(b[1:], prefix-0xf7)
+	}
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
+	if size > uint64(len(b))-offset {
+		return 0, nil, nil, ErrValueTooLarge
+	}
+	return k, b[offset : offset+size], b[offset+size:], nil
+}
+
+// readSize reads the sizeLen bytes of a long size, returning the offset of the
+// content from the prefix and its size
+func readSize(b []byte, sizeLen byte) (uint64, uint64, error) {
+
+	if len(b) < int(sizeLen) {
+		return 0, 0, io.ErrUnexpectedEOF
+	}
+	if b[0] == 0 {
+		return 0, 0, ErrCanonSize
+	}
+	var buf [8]byte
+	copy(buf[8-sizeLen:], b[:sizeLen])
+	size := binary.BigEndian.Uint64(buf[:])
+
+	// sizes below 56 must use the short form
+	if size < 56 {
+		return 0, 0, ErrCanonSize
+	}
+	return 1 + uint64(sizeLen), size, nil
+}
+
+// SplitString splits the first item off b, which must be a string
+func SplitString(b []byte) (content, rest []byte, err error) {
+	k, content, rest, err := Split(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	if k == List {
+		return nil, nil, ErrExpectedString
+	}
+	return content, rest, nil
+}
+
+// SplitList splits the first item off b, which must be a list
+func SplitList(b []byte) (content, rest []byte, err error) {
+	k, content, rest, err := Split(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	if k != List {
+		return nil, nil, ErrExpectedList
+	}
+	return content, rest, nil
+}
+
+// CountValues returns the number of items encoded in b
+func CountValues(b []byte) (int, error) {
+	n := 0
+	for len(b) > 0 {
+		_, _, rest, err := Split(b)
+		if err != nil {
+			return 0, err
+		}
+		b = rest
+		n++
+	}
+	return n, nil
+}
@@ -0,0 +1,47 @@
+package rlp
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		input   string
+		kind    Kind
+		content string
+		rest    string
+	}{
+		{"01", Byte, "01", ""},
+		{"8001", String, "", "01"},
+		{"83646f67c0", String, "646f67", "c0"},
+		{"c0", List, "", ""},
+		{"c6827a77c1040101", List, "827a77c10401", "01"},
+	}
+	for _, test := range tests {
+		kind, content, rest, err := Split(mustHex(t, test.input))
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.input, err)
+			continue
+		}
+		if kind != test.kind || !bytes.Equal(content, mustHex(t, test.content)) || !bytes.Equal(rest, mustHex(t, test.rest)) {
+			t.Errorf("%s: got %v %x %x", test.input, kind, content, rest)
+		}
+	}
+}
+
+func TestSplitStringList(t *testing.T) {
+	if _, _, err := SplitString(mustHex(t, "c0")); err != ErrExpectedString {
+		t.Errorf("SplitString: got %v, want %v", err, ErrExpectedString)
+	}
+	if _, _, err := SplitList(mustHex(t, "80")); err != ErrExpectedList {
+		t.Errorf("SplitList: got %v, want %v", err, ErrExpectedList)
+	}
+	content, rest, err := SplitList(mustHex(t, "cc83646f6783676f6483636174"))
+	if err != nil || len(rest) != 0 {
+		t.Fatalf("SplitList: got %x, %v", rest, err)
+	}
+	if n, err := CountValues(content); err != nil || n != 3 {
+		t.Errorf("CountValues: got %d, %v", n, err)
+	}
+}